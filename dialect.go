@@ -0,0 +1,58 @@
+package strftime
+
+import (
+	"strings"
+)
+
+// Dialect selects whose strftime and strptime behavior formatting and parsing reproduce
+type Dialect int
+
+const (
+	DialectGNU    Dialect = iota // GNU date and glibc, with this package's extensions (default)
+	DialectPython                // CPython's datetime.strftime and datetime.strptime on glibc
+)
+
+// pythonDateTimeFormat is %c in the C locale, which CPython inherits from glibc
+const pythonDateTimeFormat = "%a %b %e %H:%M:%S %Y"
+
+// WithDialect selects the dialect. DialectPython also writes unknown specifiers verbatim,
+// as glibc does; give WithUnknownSpecifierPolicy after it to override that.
+//
+// When parsing, DialectPython follows CPython's strptime: missing fields default to
+// 1900-01-01 00:00:00 UTC, whitespace in the format matches one or more whitespace characters,
+// literals and names match regardless of case, numbers other than %Y and %y may have fewer
+// digits, %f reads one to six digits, %I without %p is taken as AM and no trailing input is allowed.
+func WithDialect(d Dialect) Option {
+	return func(o *options) {
+		o.dialect = d
+		if d == DialectPython {
+			o.unknown = UnknownSpecifierVerbatim
+		}
+	}
+}
+
+// composite returns the pattern the composite specifier spec expands to under the options' locale and dialect
+func (o *options) composite(spec byte) string {
+	pattern := o.locale.composite(spec)
+	if o.dialect == DialectPython && spec == 'c' && pattern == defaultDateTimeFormat {
+		return pythonDateTimeFormat
+	}
+	return pattern
+}
+
+// hasPrefix reports whether s begins with prefix, ignoring case if fold is set
+func hasPrefix(s, prefix string, fold bool) bool {
+	if !fold {
+		return strings.HasPrefix(s, prefix)
+	}
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// isSpace reports whether c is an ASCII whitespace character, as matched by Python's \s in ASCII text
+func isSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '\v', '\f':
+		return true
+	}
+	return false
+}
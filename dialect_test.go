@@ -0,0 +1,112 @@
+package strftime
+
+import (
+	"testing"
+	"time"
+)
+
+// Expected values follow the examples in CPython's datetime and time documentation
+func TestPythonDialectFormat(t *testing.T) {
+	python := WithDialect(DialectPython)
+	ist := time.FixedZone("IST", 5*3600+1800)
+
+	tests := []struct {
+		t        time.Time
+		format   string
+		expected string
+	}{
+		{time.Date(2006, time.November, 21, 16, 30, 0, 0, time.UTC), "%A, %d. %B %Y %I:%M%p", "Tuesday, 21. November 2006 04:30PM"},
+		{time.Date(2002, time.December, 4, 0, 0, 0, 0, time.UTC), "%d/%m/%y", "04/12/02"},
+		{time.Date(2002, time.December, 4, 0, 0, 0, 0, time.UTC), "%A %d. %B %Y", "Wednesday 04. December 2002"},
+		{time.Date(2002, time.December, 4, 0, 0, 0, 0, time.UTC), "%c", "Wed Dec  4 00:00:00 2002"},
+		{time.Date(2002, time.December, 4, 0, 0, 0, 0, time.UTC), "%x %X", "12/04/02 00:00:00"},
+		{time.Date(2006, time.November, 21, 16, 30, 0, 0, time.UTC), "%j %U %W %w %u", "325 47 47 2 2"},
+		{time.Date(2006, time.November, 21, 16, 30, 5, 123456789, time.UTC), "%Y-%m-%dT%H:%M:%S.%f%z", "2006-11-21T16:30:05.123456+0000"},
+		{time.Date(2006, time.November, 21, 16, 30, 5, 0, ist), "%H:%M %z %:z %Z", "16:30 +0530 +05:30 IST"},
+		{time.Date(2002, time.December, 4, 0, 0, 0, 0, time.UTC), "%-d/%-m", "4/12"},
+		{time.Date(2002, time.December, 4, 0, 0, 0, 0, time.UTC), "%Q 100%", "%Q 100%"},
+		{time.Date(2002, time.December, 4, 0, 0, 0, 0, time.UTC), "%%", "%"},
+	}
+
+	for _, tt := range tests {
+		formatted := StrftimeWith(tt.format, tt.t, python)
+		if formatted != tt.expected {
+			t.Errorf("StrftimeWith(%q) with Python dialect: got [%s], expected [%s]", tt.format, formatted, tt.expected)
+		}
+		f := MustCompile(tt.format, python)
+		if formatted := f.Format(tt.t); formatted != tt.expected {
+			t.Errorf("Compiled %q with Python dialect: got [%s], expected [%s]", tt.format, formatted, tt.expected)
+		}
+	}
+
+	// The default dialect is unchanged
+	if formatted := StrftimeWith("%c %Q", time.Date(2002, time.December, 4, 0, 0, 0, 0, time.UTC)); formatted != "Wed Dec 4 00:00:00 2002 Q" {
+		t.Errorf("StrftimeWith with default dialect: got [%s]", formatted)
+	}
+	// An explicit policy after the dialect wins
+	if formatted := StrftimeWith("%Q", time.Now(), python, WithUnknownSpecifierPolicy(UnknownSpecifierDrop)); formatted != "" {
+		t.Errorf("StrftimeWith with Python dialect and drop policy: got [%s], expected []", formatted)
+	}
+}
+
+func TestPythonDialectParse(t *testing.T) {
+	python := WithDialect(DialectPython)
+
+	tests := []struct {
+		format   string
+		input    string
+		expected time.Time
+	}{
+		{"%d/%m/%y %H:%M", "21/11/06 16:30", time.Date(2006, time.November, 21, 16, 30, 0, 0, time.UTC)},
+		{"%d %b %y", "30 Nov 00", time.Date(2000, time.November, 30, 0, 0, 0, 0, time.UTC)},
+		{"%Y-%m-%dT%H:%M:%S%z", "2018-06-01T12:00:00Z", time.Date(2018, time.June, 1, 12, 0, 0, 0, time.UTC)},
+		{"%Y-%m-%dT%H:%M:%S.%f%z", "2006-11-21T16:30:00.5+01:00", time.Date(2006, time.November, 21, 15, 30, 0, 500000000, time.UTC)},
+		{"%Y-%m-%dT%H:%M:%S.%f%z", "2006-11-21T16:30:00.000123-0130", time.Date(2006, time.November, 21, 18, 0, 0, 123000, time.UTC)},
+		{"%H:%M", "16:30", time.Date(1900, time.January, 1, 16, 30, 0, 0, time.UTC)},
+		{"%Y", "2025", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"%d/%m/%Y", "5/3/2025", time.Date(2025, time.March, 5, 0, 0, 0, 0, time.UTC)},
+		{"%d %b %Y", " 5   MAR\t2025", time.Date(2025, time.March, 5, 0, 0, 0, 0, time.UTC)},
+		{"%Y-%m-%dt%H", "2025-03-05T09", time.Date(2025, time.March, 5, 9, 0, 0, 0, time.UTC)},
+		{"%I:%M", "04:30", time.Date(1900, time.January, 1, 4, 30, 0, 0, time.UTC)},
+		{"%I:%M %p", "04:30 pm", time.Date(1900, time.January, 1, 16, 30, 0, 0, time.UTC)},
+		{"%A %B %d %Y", "tuesday NOVEMBER 21 2006", time.Date(2006, time.November, 21, 0, 0, 0, 0, time.UTC)},
+		{"%c", "Tue Nov 21 16:30:00 2006", time.Date(2006, time.November, 21, 16, 30, 0, 0, time.UTC)},
+		{"%c", "Wed Dec  4 00:00:00 2002", time.Date(2002, time.December, 4, 0, 0, 0, 0, time.UTC)},
+		{"%Y %j", "2024 60", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		parsed, err := ParseWith(tt.format, tt.input, python)
+		if err != nil {
+			t.Errorf("ParseWith(%q, %q) with Python dialect returned error: %v", tt.format, tt.input, err)
+			continue
+		}
+		if !parsed.Equal(tt.expected) {
+			t.Errorf("ParseWith(%q, %q) with Python dialect: got [%v], expected [%v]", tt.format, tt.input, parsed, tt.expected)
+		}
+	}
+
+	invalid := []struct {
+		format string
+		input  string
+	}{
+		{"%S.%f", "05.1234567"},
+		{"%Y", "2025 "},
+		{"%Y %m", "2025-03"},
+		{"%Y", "25"},
+		{"%Q", "Q"},
+	}
+	for _, tt := range invalid {
+		if _, err := ParseWith(tt.format, tt.input, python); err == nil {
+			t.Errorf("ParseWith(%q, %q) with Python dialect expected error, but got none", tt.format, tt.input)
+		}
+	}
+
+	// The default dialect still needs %p and matches case exactly
+	if _, err := ParseWith("%I:%M", "04:30"); err == nil {
+		t.Error("ParseWith without AM/PM marker in the default dialect expected error, but got none")
+	}
+	if _, err := ParseWith("%b", "MAR"); err == nil {
+		t.Error("ParseWith with an uppercase month in the default dialect expected error, but got none")
+	}
+}
@@ -0,0 +1,79 @@
+package strftime
+
+import (
+	"cmp"
+	"time"
+)
+
+// Era describes a calendar era used by the %EC, %Ey and %EY specifiers
+type Era struct {
+	Start  time.Time // First day of the era, only the date is used
+	Name   string    // Era name written by %EC, such as "令和" or "พ.ศ."
+	Offset int       // Era year of the first year of the era, usually 1
+	Format string    // Strftime pattern written by %EY, "%EC%Ey" when empty
+}
+
+// JapaneseEras lists the Japanese imperial eras since the adoption of the Gregorian calendar
+var JapaneseEras = []Era{
+	{Start: time.Date(1873, time.January, 1, 0, 0, 0, 0, time.UTC), Name: "明治", Offset: 6, Format: "%EC%Ey年"},
+	{Start: time.Date(1912, time.July, 30, 0, 0, 0, 0, time.UTC), Name: "大正", Offset: 1, Format: "%EC%Ey年"},
+	{Start: time.Date(1926, time.December, 25, 0, 0, 0, 0, time.UTC), Name: "昭和", Offset: 1, Format: "%EC%Ey年"},
+	{Start: time.Date(1989, time.January, 8, 0, 0, 0, 0, time.UTC), Name: "平成", Offset: 1, Format: "%EC%Ey年"},
+	{Start: time.Date(2019, time.May, 1, 0, 0, 0, 0, time.UTC), Name: "令和", Offset: 1, Format: "%EC%Ey年"},
+}
+
+// BuddhistEras holds the Thai Buddhist era, which counts from 543 BC (astronomical year -542)
+var BuddhistEras = []Era{
+	{Start: time.Date(-542, time.January, 1, 0, 0, 0, 0, time.UTC), Name: "พ.ศ.", Offset: 1, Format: "%EC %Ey"},
+}
+
+// year returns the era year of the Gregorian year
+func (e *Era) year(year int) int {
+	return e.Offset + year - e.Start.Year()
+}
+
+// format returns the pattern written by %EY
+func (e *Era) format() string {
+	return cmp.Or(e.Format, "%EC%Ey")
+}
+
+// eraAt returns the era t falls in, the one with the latest start on or before t's date, or nil
+func (l *Locale) eraAt(t time.Time) *Era {
+	year, month, day := t.Date()
+	var found *Era
+	for k := range l.Eras {
+		e := &l.Eras[k]
+		if compareDate(e.Start, year, month, day) > 0 {
+			continue
+		}
+		if found == nil || e.Start.After(found.Start) {
+			found = e
+		}
+	}
+	return found
+}
+
+// compareDate compares the date of start with the given date
+func compareDate(start time.Time, year int, month time.Month, day int) int {
+	sy, sm, sd := start.Date()
+	if c := cmp.Compare(sy, year); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(sm, month); c != 0 {
+		return c
+	}
+	return cmp.Compare(sd, day)
+}
+
+// eraComposite returns the pattern %Ec, %Ex or %EX expands to, falling back to the plain composite
+func (l *Locale) eraComposite(spec byte) string {
+	switch spec {
+	case 'c':
+		return cmp.Or(l.EraDateTimeFormat, l.composite('c'))
+	case 'x':
+		return cmp.Or(l.EraDateFormat, l.composite('x'))
+	case 'X':
+		return cmp.Or(l.EraTimeFormat, l.composite('X'))
+	}
+	return ""
+}
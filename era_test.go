@@ -0,0 +1,137 @@
+package strftime
+
+import (
+	"testing"
+	"time"
+)
+
+// japaneseLocale is a Japanese locale with imperial eras and kanji numerals for %O
+var japaneseLocale = &Locale{
+	WeekdaysFull:      []string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+	WeekdaysAbbrev:    []string{"日", "月", "火", "水", "木", "金", "土"},
+	MonthsFull:        []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+	MonthsAbbrev:      []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+	AM:                "午前",
+	PM:                "午後",
+	Eras:              JapaneseEras,
+	EraDateFormat:     "%EY%m月%d日",
+	EraDateTimeFormat: "%EY%m月%d日 %H時%M分%S秒",
+	AltDigits:         []string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九", "十", "十一", "十二"},
+}
+
+// thaiLocale is a Thai locale with the Buddhist era and Thai digits for %O
+var thaiLocale = &Locale{
+	WeekdaysFull:   DefaultLocale.WeekdaysFull,
+	WeekdaysAbbrev: DefaultLocale.WeekdaysAbbrev,
+	MonthsFull:     DefaultLocale.MonthsFull,
+	MonthsAbbrev:   DefaultLocale.MonthsAbbrev,
+	AM:             "AM",
+	PM:             "PM",
+	Eras:           BuddhistEras,
+	EraDateFormat:  "%Od/%Om/%Ey",
+	AltDigits:      []string{"๐", "๑", "๒", "๓", "๔", "๕", "๖", "๗", "๘", "๙"},
+}
+
+func TestStrftime_JapaneseEras(t *testing.T) {
+	tests := []struct {
+		time     time.Time
+		format   string
+		expected string
+	}{
+		{time.Date(2025, time.February, 25, 15, 30, 45, 0, time.UTC), "%EC %Ey %EY", "令和 7 令和7年"},
+		{time.Date(2019, time.May, 1, 0, 0, 0, 0, time.UTC), "%EY", "令和1年"},
+		{time.Date(2019, time.April, 30, 23, 59, 59, 0, time.UTC), "%EY", "平成31年"},
+		{time.Date(1989, time.January, 7, 0, 0, 0, 0, time.UTC), "%EY", "昭和64年"},
+		{time.Date(1989, time.January, 8, 0, 0, 0, 0, time.UTC), "%EY", "平成1年"},
+		{time.Date(1900, time.June, 1, 0, 0, 0, 0, time.UTC), "%EY", "明治33年"},
+		{time.Date(1850, time.June, 1, 0, 0, 0, 0, time.UTC), "%EC|%Ey|%EY", "18|50|1850"}, // Before any era
+		{time.Date(2025, time.February, 25, 15, 30, 45, 0, time.UTC), "%Ex", "令和7年02月25日"},
+		{time.Date(2025, time.February, 25, 15, 30, 45, 0, time.UTC), "%Ec", "令和7年02月25日 15時30分45秒"},
+		{time.Date(2025, time.February, 25, 15, 30, 45, 0, time.UTC), "%EX", "15:30:45"}, // Falls back to %X
+		{time.Date(2025, time.February, 5, 9, 3, 0, 0, time.UTC), "%Om月%Od日 %OH時", "二月五日 九時"},
+		{time.Date(2025, time.December, 25, 9, 3, 0, 0, time.UTC), "%Om月%Od日", "十二月25日"}, // Beyond the list, ASCII is used
+	}
+
+	for _, tt := range tests {
+		formatted := StrftimeL(tt.format, tt.time, japaneseLocale)
+		if formatted != tt.expected {
+			t.Errorf("For format [%s] and time %v: got [%s], expected [%s]", tt.format, tt.time, formatted, tt.expected)
+		}
+		if formatted := MustCompile(tt.format, WithLocale(japaneseLocale)).Format(tt.time); formatted != tt.expected {
+			t.Errorf("For compiled format [%s] and time %v: got [%s], expected [%s]", tt.format, tt.time, formatted, tt.expected)
+		}
+	}
+}
+
+func TestStrftime_BuddhistEra(t *testing.T) {
+	testTime := time.Date(2025, time.February, 5, 9, 3, 0, 0, time.UTC)
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%EY", "พ.ศ. 2568"},
+		{"%Ey", "2568"},
+		{"%Ex", "๐๕/๐๒/2568"},
+		{"%OH:%OM", "๐๙:๐๓"},
+		{"%-Od %_Om", "๕  ๒"},
+		{"%Y", "2025"},
+	}
+
+	for _, tt := range tests {
+		formatted := StrftimeL(tt.format, testTime, thaiLocale)
+		if formatted != tt.expected {
+			t.Errorf("For format [%s]: got [%s], expected [%s]", tt.format, formatted, tt.expected)
+		}
+	}
+
+	romanized := &Locale{
+		WeekdaysFull:   DefaultLocale.WeekdaysFull,
+		WeekdaysAbbrev: DefaultLocale.WeekdaysAbbrev,
+		MonthsFull:     DefaultLocale.MonthsFull,
+		MonthsAbbrev:   DefaultLocale.MonthsAbbrev,
+		AM:             "AM",
+		PM:             "PM",
+		Eras:           []Era{{Start: time.Date(2019, time.May, 1, 0, 0, 0, 0, time.UTC), Name: "Reiwa", Offset: 1}},
+	}
+	if formatted := StrftimeL("%EC %Ey|%^EC|%EY", testTime, romanized); formatted != "Reiwa 7|REIWA|Reiwa7" {
+		t.Errorf("Romanized era: got [%s], expected [Reiwa 7|REIWA|Reiwa7]", formatted)
+	}
+}
+
+func TestParse_Eras(t *testing.T) {
+	tests := []struct {
+		loc      *Locale
+		format   string
+		input    string
+		expected string
+	}{
+		{japaneseLocale, "%EY%m月%d日", "令和7年02月25日", "2025-02-25"},
+		{japaneseLocale, "%EC%Ey年%m月%d日", "平成31年04月30日", "2019-04-30"},
+		{japaneseLocale, "%EC %Ey %m %d", "昭和 64 01 07", "1989-01-07"},
+		{japaneseLocale, "%Ex", "明治33年06月01日", "1900-06-01"},
+		{japaneseLocale, "%Y %Om月%Od日", "2025 二月五日", "2025-02-05"},
+		{japaneseLocale, "%Y %Om月%Od日", "2025 十二月25日", "2025-12-25"},
+		{thaiLocale, "%EY %m %d", "พ.ศ. 2568 02 05", "2025-02-05"},
+		{thaiLocale, "%Ex", "๐๕/๐๒/2568", "2025-02-05"},
+		{thaiLocale, "%Y %-Od/%_Om", "2025 ๕/ ๒", "2025-02-05"},
+		{DefaultLocale, "%EY-%Om-%Od", "2025-02-05", "2025-02-05"}, // Without eras and digits the prefixes are skipped
+	}
+
+	for _, tt := range tests {
+		parsedTime, err := ParseL(tt.format, tt.input, tt.loc)
+		if err != nil {
+			t.Errorf("ParseL(%q, %q) returned error: %v", tt.format, tt.input, err)
+			continue
+		}
+		if got := parsedTime.Format("2006-01-02"); got != tt.expected {
+			t.Errorf("ParseL(%q, %q): got %s, expected %s", tt.format, tt.input, got, tt.expected)
+		}
+	}
+
+	if _, err := ParseL("%EC%Ey年", "大化1年", japaneseLocale); err == nil {
+		t.Error("Expected error for an unknown era name, but got none")
+	}
+	if _, err := ParseL("%EY", "2025", japaneseLocale); err == nil {
+		t.Error("Expected error for a year without era, but got none")
+	}
+}
@@ -0,0 +1,33 @@
+package strftime
+
+import (
+	"fmt"
+)
+
+// FormatError reports an unknown or incomplete conversion specifier in a format string
+type FormatError struct {
+	Format     string // Format string or locale pattern containing the specifier
+	Offset     int    // Byte offset of the '%' starting the specifier
+	Specifier  string // Specifier as written, such as "%Q" or a trailing "%-"
+	Incomplete bool   // Whether the format ends before the conversion character
+}
+
+func (e *FormatError) Error() string {
+	if e.Incomplete {
+		return fmt.Sprintf("strftime: incomplete specifier %q at offset %d in %q", e.Specifier, e.Offset, e.Format)
+	}
+	return fmt.Sprintf("strftime: unknown specifier %q at offset %d in %q", e.Specifier, e.Offset, e.Format)
+}
+
+// resolve turns the opUnknown o, read from format[start:next], into the op the policy asks for
+func (p UnknownSpecifierPolicy) resolve(o op, format string, start, next int) (op, error) {
+	switch p {
+	case UnknownSpecifierVerbatim:
+		return op{kind: opLiteral, text: format[start:next]}, nil
+	case UnknownSpecifierDrop:
+		return op{kind: opLiteral}, nil
+	case UnknownSpecifierError:
+		return op{}, &FormatError{Format: format, Offset: start, Specifier: format[start:next], Incomplete: o.text == ""}
+	}
+	return op{kind: opLiteral, text: o.text}, nil
+}
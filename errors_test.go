@@ -0,0 +1,97 @@
+package strftime
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStrftimeE(t *testing.T) {
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+
+	formatted, err := StrftimeE("%Y-%m-%d", testTime, nil)
+	if err != nil || formatted != "2025-02-03" {
+		t.Errorf("StrftimeE valid format: got [%s], %v", formatted, err)
+	}
+
+	tests := []struct {
+		format     string
+		offset     int
+		specifier  string
+		incomplete bool
+	}{
+		{"%Y-%Q", 3, "%Q", false},
+		{"date: %_5Q", 6, "%_5Q", false},
+		{"%:Y", 0, "%:Y", false},
+		{"%::::z", 0, "%::::z", false},
+		{"%Y %", 3, "%", true},
+		{"%Y %-", 3, "%-", true},
+		{"%H%E", 2, "%E", true},
+	}
+	for _, tt := range tests {
+		_, err := StrftimeE(tt.format, testTime, nil)
+		var fe *FormatError
+		if !errors.As(err, &fe) {
+			t.Errorf("StrftimeE(%q): expected *FormatError, got %v", tt.format, err)
+			continue
+		}
+		if fe.Offset != tt.offset || fe.Specifier != tt.specifier || fe.Incomplete != tt.incomplete || fe.Format != tt.format {
+			t.Errorf("StrftimeE(%q): got %+v, expected offset %d, specifier %q, incomplete %v", tt.format, *fe, tt.offset, tt.specifier, tt.incomplete)
+		}
+	}
+
+	// Errors in the locale's patterns are reported as well
+	badLocale := *DefaultLocale
+	badLocale.DateFormat = "%m/%Q"
+	_, err = StrftimeE("%x", testTime, &badLocale)
+	var fe *FormatError
+	if !errors.As(err, &fe) || fe.Format != "%m/%Q" || fe.Offset != 3 {
+		t.Errorf("StrftimeE with bad locale pattern: got %v", err)
+	}
+
+	expected := `strftime: unknown specifier "%Q" at offset 3 in "%Y-%Q"`
+	if _, err := StrftimeE("%Y-%Q", testTime, nil); err == nil || err.Error() != expected {
+		t.Errorf("Error message: got [%v], expected [%s]", err, expected)
+	}
+}
+
+func TestUnknownSpecifierPolicy(t *testing.T) {
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+	format := "%d %Q %-5Q %"
+
+	tests := []struct {
+		policy   UnknownSpecifierPolicy
+		expected string
+	}{
+		{UnknownSpecifierChar, "03 Q Q "},
+		{UnknownSpecifierVerbatim, "03 %Q %-5Q %"},
+		{UnknownSpecifierDrop, "03   "},
+		{UnknownSpecifierError, "03 %Q %-5Q %"}, // StrftimeWith can't report errors
+	}
+	for _, tt := range tests {
+		formatted := StrftimeWith(format, testTime, WithUnknownSpecifierPolicy(tt.policy))
+		if formatted != tt.expected {
+			t.Errorf("StrftimeWith with policy %d: got [%s], expected [%s]", tt.policy, formatted, tt.expected)
+		}
+		if tt.policy == UnknownSpecifierError {
+			continue
+		}
+		f, err := Compile(format, WithUnknownSpecifierPolicy(tt.policy))
+		if err != nil {
+			t.Fatalf("Compile with policy %d returned error: %v", tt.policy, err)
+		}
+		if formatted := f.Format(testTime); formatted != tt.expected {
+			t.Errorf("Compiled with policy %d: got [%s], expected [%s]", tt.policy, formatted, tt.expected)
+		}
+	}
+
+	if _, err := Compile(format, WithUnknownSpecifierPolicy(UnknownSpecifierError)); err == nil {
+		t.Error("Compile with UnknownSpecifierError expected error, but got none")
+	}
+
+	// StrftimeE accepts a more lenient policy
+	formatted, err := StrftimeE(format, testTime, nil, WithUnknownSpecifierPolicy(UnknownSpecifierVerbatim))
+	if err != nil || formatted != "03 %Q %-5Q %" {
+		t.Errorf("StrftimeE with verbatim policy: got [%s], %v", formatted, err)
+	}
+}
@@ -0,0 +1,218 @@
+package strftime
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExplainTime is the reference time Explain renders its examples from
+var ExplainTime = time.Date(2006, time.January, 2, 15, 4, 5, 123456789, time.FixedZone("MST", -7*3600))
+
+// Explanation describes a piece of a format string
+type Explanation struct {
+	Offset      int    // Byte offset of the piece in the format
+	Text        string // Literal text or specifier as written, such as "%-d"
+	Literal     bool   // Whether Text is literal text
+	Description string // What the piece writes, in the language of the locale
+	Example     string // Text the piece writes for ExplainTime
+}
+
+func (e Explanation) String() string {
+	if e.Literal {
+		return fmt.Sprintf("%q: %s", e.Text, e.Description)
+	}
+	return fmt.Sprintf("%s: %s = %q", e.Text, e.Description, e.Example)
+}
+
+// EnglishDescriptions holds the descriptions Explain uses, keyed by specifier without '%' and modifier, and by
+// modifier. A Locale's Descriptions use the same keys, and entries they lack are taken from here.
+var EnglishDescriptions = map[string]string{
+	"A":     "full weekday name",
+	"a":     "abbreviated weekday name",
+	"B":     "full month name",
+	"b":     "abbreviated month name",
+	"h":     "abbreviated month name",
+	"C":     "century, zero-padded (00–99)",
+	"c":     "date and time",
+	"D":     "month/day/year date",
+	"d":     "day of month, zero-padded (01–31)",
+	"e":     "day of month, space-padded (1–31)",
+	"F":     "ISO 8601 date",
+	"f":     "microseconds (000000–999999)",
+	"G":     "ISO 8601 week-based year",
+	"g":     "ISO 8601 week-based year without century (00–99)",
+	"H":     "hour, zero-padded (00–23)",
+	"I":     "hour of the 12-hour clock, zero-padded (01–12)",
+	"i":     "day of quarter, zero-padded (01–92)",
+	"J":     "half of the year (1–2)",
+	"j":     "day of year, zero-padded (001–366)",
+	"K":     "week of month (1–6)",
+	"k":     "hour, space-padded (0–23)",
+	"L":     "milliseconds (000–999)",
+	"l":     "hour of the 12-hour clock, space-padded (1–12)",
+	"M":     "minute, zero-padded (00–59)",
+	"m":     "month, zero-padded (01–12)",
+	"N":     "nanoseconds (000000000–999999999)",
+	"n":     "newline",
+	"o":     "day of month with ordinal suffix",
+	"p":     "AM or PM",
+	"q":     "quarter (1–4)",
+	"R":     "hour and minute",
+	"r":     "12-hour clock time",
+	"S":     "second, zero-padded (00–60)",
+	"s":     "seconds since the Unix epoch",
+	"T":     "time",
+	"t":     "tab",
+	"U":     "week of year, weeks starting on Sunday (00–53)",
+	"u":     "day of week, Monday is 1 (1–7)",
+	"V":     "ISO 8601 week number (01–53)",
+	"v":     "day-month-year date",
+	"W":     "week of year, weeks starting on Monday (00–53)",
+	"w":     "day of week, Sunday is 0 (0–6)",
+	"X":     "time",
+	"x":     "date",
+	"Y":     "year",
+	"y":     "year without century (00–99)",
+	"Z":     "time zone abbreviation",
+	":Z":    "short localized time zone name",
+	"::Z":   "long localized time zone name",
+	":::Z":  "generic localized time zone name",
+	"z":     "UTC offset (+hhmm)",
+	":z":    "UTC offset (+hh:mm)",
+	"::z":   "UTC offset (+hh:mm:ss)",
+	":::z":  "UTC offset with as many colons as needed",
+	"Ez":    "UTC offset, Z for UTC (+hhmm)",
+	":Ez":   "UTC offset, Z for UTC (+hh:mm)",
+	"::Ez":  "UTC offset, Z for UTC (+hh:mm:ss)",
+	":::Ez": "UTC offset, Z for UTC, with as many colons as needed",
+	"EC":    "era name",
+	"Ey":    "year of the era",
+	"EY":    "full era year",
+	"Ec":    "era date and time",
+	"Ex":    "era date",
+	"EX":    "era time",
+	"+":     "date and time as date(1) writes it",
+	"%":     "percent sign",
+
+	// Modifiers, added to the description of the specifier
+	"-":      "not padded",
+	"_":      "padded with spaces",
+	"0":      "padded with zeros",
+	"^":      "uppercase",
+	"#":      "opposite case",
+	"*":      "with ordinal suffix",
+	"O":      "with alternative digits",
+	"width":  "padded to %d characters",
+	"digits": "%d digits",
+
+	// Default padding in the descriptions above, left out when a flag or width changes the padding
+	"zero-padded":  "zero-padded",
+	"space-padded": "space-padded",
+
+	// Pieces other than built-in specifiers
+	"literal":    "literal text",
+	"custom":     "custom specifier",
+	"unknown":    "unknown specifier",
+	"incomplete": "incomplete specifier",
+}
+
+// description returns the description for key in the locale, falling back to EnglishDescriptions
+func (l *Locale) description(key string) string {
+	if d, ok := l.Descriptions[key]; ok {
+		return d
+	}
+	return EnglishDescriptions[key]
+}
+
+// Explain breaks format into literal text and specifiers, describing each in the language of loc and
+// rendering it for ExplainTime. Composite specifiers show the pattern they expand to, and flags are only
+// described where they change the output. A nil loc selects DefaultLocale.
+func Explain(format string, loc *Locale) []Explanation {
+	opts := newOptions([]Option{WithLocale(loc)})
+	var explanations []Explanation
+	for t, o := range tokens(format, &opts) {
+		e := Explanation{Offset: t.Start, Text: t.Text}
+		if t.Kind == TokenLiteral && t.Specifier == "" {
+			e.Literal, e.Description, e.Example = true, opts.locale.description("literal"), t.Text
+		} else {
+			e.Description, e.Example = describe(t, o, &opts), string(appendFormat(nil, t.Text, ExplainTime, &opts))
+		}
+		explanations = append(explanations, e)
+	}
+	return explanations
+}
+
+// describe returns the description of the specifier t, which stands for o
+func describe(t Token, o op, opts *options) string {
+	loc := opts.locale
+	switch o.kind {
+	case opUnknown:
+		if t.Specifier == "" {
+			return loc.description("incomplete")
+		}
+		return loc.description("unknown")
+	case opCustom:
+		return loc.description("custom")
+	}
+
+	conversion := t.spec("", 0, t.Alt)[1:]
+	description, ok := loc.Descriptions[conversion]
+	if !ok {
+		description, ok = EnglishDescriptions[conversion]
+	}
+	var modifiers []string
+	if !ok && t.Alt != 0 {
+		// An E or O modifier without a description of its own
+		description = loc.description(t.spec("", 0, 0)[1:])
+		if t.Alt == 'O' && !ignored(t.Text, t.spec(t.Flags, t.Width, 0), opts) {
+			modifiers = append(modifiers, loc.description("O"))
+		}
+	}
+	if o.kind == opComposite && o.text != "" {
+		description += " (" + o.text + ")"
+	}
+
+	repadded := false
+	for k := range len(t.Flags) {
+		if !ignored(t.Text, t.spec(t.Flags[:k]+t.Flags[k+1:], t.Width, t.Alt), opts) {
+			modifiers = append(modifiers, loc.description(t.Flags[k:k+1]))
+			repadded = repadded || strings.IndexByte("-_0*", t.Flags[k]) >= 0
+		}
+	}
+	if t.Width > 0 && !ignored(t.Text, t.spec(t.Flags, 0, t.Alt), opts) {
+		key := "width"
+		if o.kind == opFraction {
+			key = "digits"
+		} else {
+			repadded = true
+		}
+		modifiers = append(modifiers, fmt.Sprintf(loc.description(key), t.Width))
+	}
+	if repadded {
+		// The modifiers describe the padding instead, and the range is written as the op pads it
+		for _, key := range []string{"zero-padded", "space-padded"} {
+			description = strings.Replace(description, ", "+loc.description(key), "", 1)
+		}
+		if def := lookupSpec(t.Specifier[0]); o.kind == opNumber && def != nil && def.max > 0 {
+			description = strings.Replace(description, valueRange(def.min, def.max, def.width, cmp.Or(def.pad, '0')),
+				valueRange(def.min, def.max, o.digits, o.pad), 1)
+		}
+	}
+
+	if len(modifiers) == 0 {
+		return description
+	}
+	return description + ", " + strings.Join(modifiers, ", ")
+}
+
+// valueRange returns the range from lo to hi as descriptions write it, such as "(01–31)",
+// with the bounds zero-padded to digits if pad is '0'
+func valueRange(lo, hi, digits int, pad byte) string {
+	if pad != '0' {
+		digits = 0
+	}
+	b := append(appendInt([]byte{'('}, int64(lo), digits, '0'), "–"...)
+	return string(append(appendInt(b, int64(hi), digits, '0'), ')'))
+}
@@ -0,0 +1,103 @@
+package strftime
+
+import (
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected []string
+	}{
+		{"%a %e %b", []string{
+			`%a: abbreviated weekday name = "Mon"`,
+			`" ": literal text`,
+			`%e: day of month, space-padded (1–31) = " 2"`,
+			`" ": literal text`,
+			`%b: abbreviated month name = "Jan"`,
+		}},
+		{"at %l:%M%p %Z", []string{
+			`"at ": literal text`,
+			`%l: hour of the 12-hour clock, space-padded (1–12) = " 3"`,
+			`":": literal text`,
+			`%M: minute, zero-padded (00–59) = "04"`,
+			`%p: AM or PM = "PM"`,
+			`" ": literal text`,
+			`%Z: time zone abbreviation = "MST"`,
+		}},
+		{"%-d%^B%10A%3N", []string{
+			`%-d: day of month (1–31), not padded = "2"`,
+			`%^B: full month name, uppercase = "JANUARY"`,
+			`%10A: full weekday name, padded to 10 characters = "    Monday"`,
+			`%3N: nanoseconds (000000000–999999999), 3 digits = "123"`,
+		}},
+		{"%^d%Od%:Ez%*d", []string{
+			`%^d: day of month, zero-padded (01–31) = "02"`,
+			`%Od: day of month, zero-padded (01–31) = "02"`,
+			`%:Ez: UTC offset, Z for UTC (+hh:mm) = "-07:00"`,
+			`%*d: day of month (1–31), with ordinal suffix = "2nd"`,
+		}},
+		{"%_d%0e%-l%5H", []string{
+			`%_d: day of month (1–31), padded with spaces = " 2"`,
+			`%0e: day of month (01–31), padded with zeros = "02"`,
+			`%-l: hour of the 12-hour clock (1–12), not padded = "3"`,
+			`%5H: hour (00000–00023), padded to 5 characters = "00015"`,
+		}},
+		{"%F%%%Q%", []string{
+			`%F: ISO 8601 date (%Y-%m-%d) = "2006-01-02"`,
+			`%%: percent sign = "%"`,
+			`%Q: unknown specifier = "Q"`,
+			`%: incomplete specifier = ""`,
+		}},
+	}
+
+	for _, tt := range tests {
+		explanations := Explain(tt.format, nil)
+		if len(explanations) != len(tt.expected) {
+			t.Errorf("Explain(%q): got %d pieces %v, expected %d", tt.format, len(explanations), explanations, len(tt.expected))
+			continue
+		}
+		for k, e := range explanations {
+			if got := e.String(); got != tt.expected[k] {
+				t.Errorf("Explain(%q)[%d]: got [%s], expected [%s]", tt.format, k, got, tt.expected[k])
+			}
+		}
+	}
+
+	explanations := Explain("on %d", nil)
+	if e := explanations[1]; e.Offset != 3 || e.Literal || e.Text != "%d" || e.Example != "02" {
+		t.Errorf("Explain fields: got %+v", e)
+	}
+	if e := explanations[0]; e.Offset != 0 || !e.Literal || e.Example != "on " {
+		t.Errorf("Explain literal fields: got %+v", e)
+	}
+}
+
+func TestExplain_Locale(t *testing.T) {
+	german := *DefaultLocale
+	german.WeekdaysFull = []string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"}
+	german.AltDigits = []string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+	german.Descriptions = map[string]string{
+		"A":       "Wochentag",
+		"d":       "Tag des Monats, zweistellig (01–31)",
+		"^":       "in Großbuchstaben",
+		"O":       "mit alternativen Ziffern",
+		"literal": "Text",
+	}
+
+	expected := []string{
+		`%^A: Wochentag, in Großbuchstaben = "MONTAG"`,
+		`", ": Text`,
+		`%Od: Tag des Monats, zweistellig (01–31), mit alternativen Ziffern = "〇二"`,
+		`%B: full month name = "January"`,
+	}
+	explanations := Explain("%^A, %Od%B", &german)
+	if len(explanations) != len(expected) {
+		t.Fatalf("Explain: got %v, expected %d pieces", explanations, len(expected))
+	}
+	for k, e := range explanations {
+		if got := e.String(); got != expected[k] {
+			t.Errorf("Explain[%d]: got [%s], expected [%s]", k, got, expected[k])
+		}
+	}
+}
@@ -1,9 +1,10 @@
 package strftime
 
 import (
-	"fmt"
+	"cmp"
+	"io"
 	"strconv"
-	"strings"
+	"sync"
 	"time"
 )
 
@@ -14,249 +15,362 @@ func Strftime(format string, t time.Time) string {
 
 // StrftimeL formats time according to the specified format string and locale
 func StrftimeL(format string, t time.Time, loc *Locale) string {
+	return string(AppendStrftime(make([]byte, 0, len(format)*2), format, t, loc))
+}
+
+// StrftimeE formats time according to the specified format string and locale.
+// Unknown or incomplete specifiers are reported as a *FormatError unless opts select another UnknownSpecifierPolicy.
+func StrftimeE(format string, t time.Time, loc *Locale, opts ...Option) (string, error) {
+	opts = append([]Option{WithUnknownSpecifierPolicy(UnknownSpecifierError)}, opts...)
+	f, err := Compile(format, append(opts, WithLocale(loc))...)
+	if err != nil {
+		return "", err
+	}
+	return f.Format(t), nil
+}
+
+// StrftimeWith formats time according to the specified format string and options.
+// It cannot report errors, so UnknownSpecifierError writes unknown specifiers verbatim; use StrftimeE to detect them.
+func StrftimeWith(format string, t time.Time, opts ...Option) string {
+	o := newOptions(opts)
+	return string(appendFormat(make([]byte, 0, len(format)*2), format, t, &o))
+}
+
+// AppendStrftime formats time according to the specified format string and locale and appends the result to dst.
+// No allocations are made as long as dst has enough capacity for the output.
+func AppendStrftime(dst []byte, format string, t time.Time, loc *Locale) []byte {
 	if loc == nil {
 		loc = DefaultLocale
 	}
+	o := options{locale: loc}
+	return appendFormat(dst, format, t, &o)
+}
+
+// FormatTo formats time according to the specified format string and locale and writes the result to w.
+// It returns the number of bytes written and any error returned by w.
+func FormatTo(w io.Writer, format string, t time.Time, loc *Locale) (int, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	*buf = AppendStrftime((*buf)[:0], format, t, loc)
+	return w.Write(*buf)
+}
+
+// bufferPool holds scratch buffers for FormatTo
+var bufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, 64)
+		return &buf
+	},
+}
+
+func getBuffer() *[]byte {
+	return bufferPool.Get().(*[]byte)
+}
+
+func putBuffer(buf *[]byte) {
+	// Don't keep oversized buffers around
+	if cap(*buf) > 1<<10 {
+		return
+	}
+	bufferPool.Put(buf)
+}
 
-	var result strings.Builder
+// appendFormat interprets format directly and appends the result to dst
+func appendFormat(dst []byte, format string, t time.Time, opts *options) []byte {
+	var o op
 	i := 0
 	for i < len(format) {
 		if format[i] != '%' {
-			result.WriteByte(format[i])
-			i++
+			start := i
+			for i < len(format) && format[i] != '%' {
+				i++
+			}
+			dst = append(dst, format[start:i]...)
 			continue
 		}
 
-		// Handle % directives
-		i++
-		if i >= len(format) {
-			break
+		next := scanSpecifier(format, i, opts, &o)
+		if o.kind == opUnknown {
+			var err error
+			if o, err = opts.unknown.resolve(o, format, i, next); err != nil {
+				// Errors can't be reported here, StrftimeE compiles the format to report them
+				o = op{kind: opLiteral, text: format[i:next]}
+			}
 		}
+		dst = o.appendTo(dst, t, opts)
+		i = next
+	}
+	return dst
+}
 
-		// Handle %% escape sequence to produce a single %
-		if format[i] == '%' {
-			result.WriteByte('%')
-			i++
-			continue
-		}
+// scanDirective reads the specifier that starts at format[i] == '%' with the grammar of scanToken.
+// It returns the op the specifier stands for and the index just past it.
+func scanDirective(format string, i int, opts *options) (o op, next int) {
+	next = scanSpecifier(format, i, opts, &o)
+	return o, next
+}
 
-		// Handle format modifiers for GNU libc extension
-		padChar := byte('0')
-		padWidth := 2
+// scanSpecifier reads the specifier that starts at format[i] == '%' into o, without building its Token,
+// and returns the index just past it
+func scanSpecifier(format string, i int, opts *options, o *op) int {
+	var m Modifiers
+	_, j := scanModifiers(format, i, &m)
+	return j + scanConversion(format, j, &m, opts, o)
+}
 
-		switch format[i] {
-		case '-': // No padding
-			padWidth = 0
-			i++
-		case '_': // Space padding
-			padChar = ' '
-			i++
-		case '0': // Zero padding (default)
-			i++
-		}
+// Modifiers holds the flags and field width given between '%' and the conversion character
+type Modifiers struct {
+	Pad    byte // Last padding flag: '-', '_' or '0', 0 if none was given
+	Width  int  // Field width, 0 if none was given
+	Upper  bool // '^' flag
+	Swap   bool // '#' flag
+	Colons int  // Number of colons before the conversion character, as in %:z
+	Alt    byte // POSIX 'E' (era) or 'O' (alternative digits) modifier, 0 if none was given
+
+	Ordinal bool // '*' flag, or the %o specifier
+}
 
-		if i >= len(format) {
+// textCase returns the case conversion selected by the flags; '^' takes precedence over '#'
+func (m Modifiers) textCase() caseMode {
+	switch {
+	case m.Upper:
+		return caseUpper
+	case m.Swap:
+		return caseSwap
+	}
+	return caseNone
+}
+
+// directiveOp sets o to the op for the one-byte conversion spec under the given modifiers and options,
+// as its entry in specDefs describes it, and returns that entry, nil if there is none
+func directiveOp(o *op, spec string, m *Modifiers, opts *options) *specDef {
+	def := lookupSpec(spec[0])
+	// Colons are only meaningful for %z and %Z
+	if def == nil || m.Colons > def.colons {
+		*o = op{kind: opUnknown, text: spec}
+		return def
+	}
+	d := directive{spec: spec[0], m: m, ordinal: m.Ordinal, opts: opts}
+	d.op(o, def)
+	if m.Alt == 'O' { // Alternative digits for numeric specifiers
+		o.alt = o.kind == opNumber
+	}
+	return def
+}
+
+// op sets o to the op of the directive, whose entry in specDefs is def
+func (d *directive) op(o *op, def *specDef) {
+	m := d.m
+	switch def.format {
+	case formatNumber:
+		if m.Alt == 'E' && def.eraWidth > 0 {
+			d.era(o, def.field, def.eraWidth)
 			break
 		}
-
-		// Handle POSIX locale extensions
-		if format[i] == 'E' || format[i] == 'O' {
-			i++
-			if i >= len(format) {
-				break
-			}
+		d.ordinal = d.ordinal || def.ordinal
+		d.number(o, def.field, def.width, cmp.Or(def.pad, '0'))
+	case formatYear:
+		if m.Alt == 'E' && def.eraWidth > 0 {
+			d.era(o, def.field, def.eraWidth)
+			break
 		}
+		d.year(o, def.field)
+	case formatFraction:
+		d.fraction(o, def.width)
+	case formatName:
+		d.text(o, op{kind: opName, names: def.names})
+	case formatLiteral:
+		d.text(o, op{kind: opLiteral, text: def.text})
+	case formatEraComposite:
+		if m.Alt == 'E' { // Locale's alternative date and time representations
+			d.text(o, op{kind: opComposite, text: d.opts.locale.eraComposite(d.spec)})
+			break
+		}
+		d.composite(o)
+	case formatComposite:
+		d.composite(o)
+	case formatZone:
+		if m.Colons == 0 {
+			d.text(o, op{kind: opLayout, text: "MST"})
+			break
+		}
+		d.text(o, op{kind: opZoneName, digits: m.Colons})
+	case formatOffset:
+		d.text(o, op{kind: opOffset, digits: m.Colons, zulu: d.opts.zulu || m.Alt == 'E'})
+	default:
+		*o = op{kind: opUnknown, text: string(rune(d.spec))}
+	}
+}
 
-		switch format[i] {
-		case 'A': // Full weekday name
-			result.WriteString(loc.WeekdaysFull[t.Weekday()])
-		case 'a': // Abbreviated weekday name
-			result.WriteString(loc.WeekdaysAbbrev[t.Weekday()])
-		case 'B': // Full month name
-			result.WriteString(loc.MonthsFull[t.Month()-1])
-		case 'b', 'h': // Abbreviated month name
-			result.WriteString(loc.MonthsAbbrev[t.Month()-1])
-		case 'C': // Century
-			century := t.Year() / 100
-			if padWidth > 0 {
-				result.WriteString(formatInt(century, padWidth, padChar))
-			} else {
-				result.WriteString(strconv.Itoa(century))
-			}
-		case 'c': // Date and time representation
-			result.WriteString(t.Format("Mon Jan 2 15:04:05 2006"))
-		case 'D': // %m/%d/%y
-			result.WriteString(t.Format("01/02/06"))
-		case 'd': // Day of month (01-31)
-			if padWidth > 0 {
-				result.WriteString(formatInt(t.Day(), padWidth, padChar))
-			} else {
-				result.WriteString(strconv.Itoa(t.Day()))
-			}
-		case 'e': // Day of month (space-padded)
-			result.WriteString(fmt.Sprintf("%2d", t.Day()))
-		case 'F': // ISO 8601 date
-			result.WriteString(t.Format("2006-01-02"))
-		case 'G': // ISO 8601 year
-			year, _ := t.ISOWeek()
-			if padWidth > 0 {
-				result.WriteString(formatInt(year, 4, padChar))
-			} else {
-				result.WriteString(strconv.Itoa(year))
-			}
-		case 'g': // ISO 8601 year (2 digits)
-			year, _ := t.ISOWeek()
-			if padWidth > 0 {
-				result.WriteString(formatInt(year%100, 2, padChar))
-			} else {
-				result.WriteString(strconv.Itoa(year % 100))
-			}
-		case 'H': // Hour in 24h format (00-23)
-			if padWidth > 0 {
-				result.WriteString(formatInt(t.Hour(), padWidth, padChar))
-			} else {
-				result.WriteString(strconv.Itoa(t.Hour()))
-			}
-		case 'I': // Hour in 12h format (01-12)
-			hour := t.Hour() % 12
-			if hour == 0 {
-				hour = 12
-			}
-			if padWidth > 0 {
-				result.WriteString(formatInt(hour, padWidth, padChar))
-			} else {
-				result.WriteString(strconv.Itoa(hour))
-			}
-		case 'j': // Day of year (001-366)
-			yday := t.YearDay()
-			if padWidth > 0 {
-				result.WriteString(formatInt(yday, 3, padChar))
-			} else {
-				result.WriteString(strconv.Itoa(yday))
-			}
-		case 'k': // Hour in 24h format (space-padded)
-			result.WriteString(fmt.Sprintf("%2d", t.Hour()))
-		case 'l': // Hour in 12h format (space-padded)
-			hour := t.Hour() % 12
-			if hour == 0 {
-				hour = 12
-			}
-			result.WriteString(fmt.Sprintf("%2d", hour))
-		case 'M': // Minute (00-59)
-			if padWidth > 0 {
-				result.WriteString(formatInt(t.Minute(), padWidth, padChar))
-			} else {
-				result.WriteString(strconv.Itoa(t.Minute()))
-			}
-		case 'm': // Month (01-12)
-			if padWidth > 0 {
-				result.WriteString(formatInt(int(t.Month()), padWidth, padChar))
-			} else {
-				result.WriteString(strconv.Itoa(int(t.Month())))
-			}
-		case 'n': // Newline
-			result.WriteString("\n")
-		case 'p': // AM/PM
-			if t.Hour() < 12 {
-				result.WriteString(loc.AM)
-			} else {
-				result.WriteString(loc.PM)
-			}
-		case 'R': // %H:%M
-			result.WriteString(t.Format("15:04"))
-		case 'r': // %I:%M:%S %p
-			h := t.Hour() % 12
-			if h == 0 {
-				h = 12
-			}
-			ampm := loc.AM
-			if t.Hour() >= 12 {
-				ampm = loc.PM
-			}
-			result.WriteString(fmt.Sprintf("%02d:%02d:%02d %s", h, t.Minute(), t.Second(), ampm))
-		case 'S': // Second (00-59)
-			if padWidth > 0 {
-				result.WriteString(formatInt(t.Second(), padWidth, padChar))
-			} else {
-				result.WriteString(strconv.Itoa(t.Second()))
-			}
-		case 's': // Seconds since Unix epoch
-			result.WriteString(strconv.FormatInt(t.Unix(), 10))
-		case 'T': // %H:%M:%S
-			result.WriteString(t.Format("15:04:05"))
-		case 't': // Tab
-			result.WriteString("\t")
-		case 'U': // Week number (Sunday first day)
-			_, week := t.ISOWeek()
-			if padWidth > 0 {
-				result.WriteString(formatInt(week, 2, padChar))
-			} else {
-				result.WriteString(strconv.Itoa(week))
-			}
-		case 'u': // Weekday (1-7, Monday is 1)
-			wd := int(t.Weekday())
-			if wd == 0 {
-				wd = 7 // Sunday should be 7 in this format
-			}
-			result.WriteString(strconv.Itoa(wd))
-		case 'V': // ISO 8601 week number
-			_, week := t.ISOWeek()
-			if padWidth > 0 {
-				result.WriteString(formatInt(week, 2, padChar))
-			} else {
-				result.WriteString(strconv.Itoa(week))
-			}
-		case 'v': // %e-%b-%Y
-			result.WriteString(fmt.Sprintf("%2d-%s-%04d", t.Day(), loc.MonthsAbbrev[t.Month()-1], t.Year()))
-		case 'W': // Week number (Monday first day)
-			_, week := t.ISOWeek()
-			if padWidth > 0 {
-				result.WriteString(formatInt(week, 2, padChar))
-			} else {
-				result.WriteString(strconv.Itoa(week))
-			}
-		case 'w': // Weekday (0-6, Sunday is 0)
-			result.WriteString(strconv.Itoa(int(t.Weekday())))
-		case 'X': // Time representation
-			result.WriteString(t.Format("15:04:05"))
-		case 'x': // Date representation
-			result.WriteString(t.Format("01/02/06"))
-		case 'Y': // Year with century
-			if padWidth > 0 {
-				result.WriteString(formatInt(t.Year(), 4, padChar))
-			} else {
-				result.WriteString(strconv.Itoa(t.Year()))
-			}
-		case 'y': // Year without century
-			if padWidth > 0 {
-				result.WriteString(formatInt(t.Year()%100, 2, padChar))
-			} else {
-				result.WriteString(strconv.Itoa(t.Year() % 100))
-			}
-		case 'Z': // Time zone name
-			result.WriteString(t.Format("MST"))
-		case 'z': // Time zone offset
-			result.WriteString(t.Format("-0700"))
-		case '+': // Date and time like date(1)
-			result.WriteString(t.Format("Mon Jan 2 15:04:05 MST 2006"))
-		case '%': // Literal %
-			result.WriteByte('%')
-		default:
-			result.WriteByte(format[i])
+// directive is a conversion character with its modifiers, which directiveOp builds an op for
+type directive struct {
+	spec    byte
+	m       *Modifiers
+	ordinal bool // Whether the '*' flag was given or the specifier is an ordinal, as %o is
+	opts    *options
+}
+
+// number sets o to a numeric op, digits and pad are the defaults used when no width or padding flag is given
+func (d *directive) number(o *op, f field, digits int, pad byte) {
+	m := d.m
+	if d.ordinal {
+		// Ordinals are not padded unless a width is given
+		digits = 0
+	}
+	if m.Width > 0 {
+		digits = m.Width
+	}
+	switch m.Pad {
+	case '-':
+		digits = 0
+	case '_':
+		pad = ' '
+	case '0':
+		pad = '0'
+	}
+	*o = op{kind: opNumber, field: f, digits: digits, pad: pad}
+	if d.ordinal {
+		o.ordinal, o.textCase = true, m.textCase()
+	}
+}
+
+// text sets o to base with the case flags applied, padded to the field width with spaces unless the '0' flag is given
+func (d *directive) text(o *op, base op) {
+	*o = base
+	o.textCase = d.m.textCase()
+	o.width = d.m.Width
+	o.pad = ' '
+	switch d.m.Pad {
+	case '-':
+		o.width = 0
+	case '0':
+		o.pad = '0'
+	}
+}
+
+// year sets o to a full year, signed and with opts.yearDigits digits if expanded years are enabled
+func (d *directive) year(o *op, f field) {
+	if d.opts.yearDigits == 0 {
+		d.number(o, f, 4, '0')
+		return
+	}
+	d.number(o, f, d.opts.yearDigits, '0')
+	o.sign = true
+}
+
+// composite sets o to expand to the locale's pattern for the conversion
+func (d *directive) composite(o *op) {
+	d.text(o, op{kind: opComposite, text: d.opts.composite(d.spec)})
+}
+
+// era sets o to the locale's era, falling back to field with the given digits outside any era
+func (d *directive) era(o *op, f field, digits int) {
+	d.number(o, f, digits, '0')
+	o.kind = opEra
+	o.textCase = d.m.textCase()
+}
+
+// fraction sets o to fractional seconds, digits is used when no width is given
+func (d *directive) fraction(o *op, digits int) {
+	if d.m.Width > 0 {
+		digits = d.m.Width
+	}
+	*o = op{kind: opFraction, digits: digits}
+}
+
+// appendInt appends value to dst, left-padded with padChar to at least width digits.
+// The sign of a negative value is not counted; zero padding goes after it, as in -0045, other padding before it.
+func appendInt(dst []byte, value int64, width int, padChar byte) []byte {
+	var buf [20]byte
+	digits := strconv.AppendInt(buf[:0], value, 10)
+	if value < 0 {
+		if padChar == '0' {
+			dst = append(dst, '-')
+			digits = digits[1:]
+		} else {
+			width++
 		}
-		i++
 	}
+	for n := len(digits); n < width; n++ {
+		dst = append(dst, padChar)
+	}
+	return append(dst, digits...)
+}
 
-	return result.String()
+// appendAltDigits appends value using the locale's alternative digits.
+// A list of ten substitutes each decimal digit of the padded number; longer lists are
+// indexed by value, as POSIX alt_digits are, and values outside them use ASCII digits.
+func appendAltDigits(dst []byte, value int64, digits int, pad byte, alt []string) []byte {
+	if len(alt) != 10 {
+		if value >= 0 && value < int64(len(alt)) {
+			return append(dst, alt[value]...)
+		}
+		return appendInt(dst, value, digits, pad)
+	}
+	var buf [24]byte
+	for _, c := range appendInt(buf[:0], value, digits, pad) {
+		if c >= '0' && c <= '9' {
+			dst = append(dst, alt[c-'0']...)
+		} else {
+			dst = append(dst, c)
+		}
+	}
+	return dst
 }
 
-// formatInt formats an integer with specified padding
-func formatInt(value, width int, padChar byte) string {
-	s := strconv.Itoa(value)
-	if len(s) >= width {
-		return s
+// appendOffset appends the UTC offset in seconds to dst in the form selected by colons, as in %z to %:::z.
+// With zulu set a zero offset is written as "Z".
+func appendOffset(dst []byte, offset, colons int, zulu bool) []byte {
+	if zulu && offset == 0 {
+		return append(dst, 'Z')
+	}
+	sign := byte('+')
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	hours, minutes, seconds := offset/3600, offset/60%60, offset%60
+
+	dst = append(dst, sign)
+	dst = appendInt(dst, int64(hours), 2, '0')
+	switch colons {
+	case 0:
+		dst = appendInt(dst, int64(minutes), 2, '0')
+	case 1:
+		dst = append(dst, ':')
+		dst = appendInt(dst, int64(minutes), 2, '0')
+	case 2:
+		dst = append(dst, ':')
+		dst = appendInt(dst, int64(minutes), 2, '0')
+		dst = append(dst, ':')
+		dst = appendInt(dst, int64(seconds), 2, '0')
+	default:
+		if minutes != 0 || seconds != 0 {
+			dst = append(dst, ':')
+			dst = appendInt(dst, int64(minutes), 2, '0')
+		}
+		if seconds != 0 {
+			dst = append(dst, ':')
+			dst = appendInt(dst, int64(seconds), 2, '0')
+		}
 	}
+	return dst
+}
 
-	padding := strings.Repeat(string(padChar), width-len(s))
-	return padding + s
+// appendFraction appends the first digits digits of the fractional second nsec to dst.
+// Digits beyond nanosecond precision are written as zeros.
+func appendFraction(dst []byte, nsec, digits int) []byte {
+	var buf [9]byte
+	for k := len(buf) - 1; k >= 0; k-- {
+		buf[k] = byte('0' + nsec%10)
+		nsec /= 10
+	}
+	if digits <= len(buf) {
+		return append(dst, buf[:digits]...)
+	}
+	dst = append(dst, buf[:]...)
+	for n := len(buf); n < digits; n++ {
+		dst = append(dst, '0')
+	}
+	return dst
 }
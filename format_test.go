@@ -2,6 +2,8 @@ package strftime
 
 import (
 	"fmt"
+	"io"
+	"strings"
 	"testing"
 	"time"
 )
@@ -265,3 +267,490 @@ func TestStrftime_CompleteFormatCoverage(t *testing.T) {
 		}
 	}
 }
+
+func TestAppendStrftime(t *testing.T) {
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+	dst := []byte("time=")
+	dst = AppendStrftime(dst, "%Y-%m-%d %H:%M:%S", testTime, nil)
+	expected := "time=2025-02-03 09:05:07"
+	if string(dst) != expected {
+		t.Errorf("AppendStrftime failed, got [%s], expected [%s]", dst, expected)
+	}
+}
+
+func TestFormatTo(t *testing.T) {
+	testTime := time.Date(2025, time.February, 3, 15, 5, 7, 0, time.UTC)
+	var sb strings.Builder
+	n, err := FormatTo(&sb, "%A %I:%M %p", testTime, DefaultLocale)
+	expected := "Monday 03:05 PM"
+	if err != nil {
+		t.Fatalf("FormatTo returned error: %v", err)
+	}
+	if sb.String() != expected || n != len(expected) {
+		t.Errorf("FormatTo failed, got [%s] (%d bytes), expected [%s]", sb.String(), n, expected)
+	}
+}
+
+func TestAppendStrftime_ZeroAllocs(t *testing.T) {
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+	formats := []string{
+		"%Y-%m-%d %H:%M:%S",
+		"%-d/%-m/%-Y %_H:%_M:%_S",
+		"%C %e %G %g %I %j %k %l %s %u %V %w %y",
+		"%a %b %p %F %T %r %z",
+		"%^a %#p %10A %_5d %012s %^-10B",
+		"%EY %Ey %Od %:z %::z %Ez %q %K %o %*d %3N",
+	}
+	buf := make([]byte, 0, 256)
+	for _, format := range formats {
+		allocs := testing.AllocsPerRun(100, func() {
+			buf = AppendStrftime(buf[:0], format, testTime, DefaultLocale)
+		})
+		if allocs != 0 {
+			t.Errorf("AppendStrftime(%q) made %v allocations per call, expected 0", format, allocs)
+		}
+	}
+}
+
+func BenchmarkStrftime(b *testing.B) {
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+	b.ReportAllocs()
+	for b.Loop() {
+		Strftime("%Y-%m-%d %H:%M:%S", testTime)
+	}
+}
+
+func BenchmarkAppendStrftime(b *testing.B) {
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = AppendStrftime(buf[:0], "%Y-%m-%d %H:%M:%S", testTime, DefaultLocale)
+	}
+}
+
+func BenchmarkAppendStrftime_AllNumeric(b *testing.B) {
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+	buf := make([]byte, 0, 128)
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = AppendStrftime(buf[:0], "%C %d %e %G %g %H %I %j %k %l %M %m %S %s %u %V %w %Y %y", testTime, DefaultLocale)
+	}
+}
+
+func BenchmarkAppendStrftime_Modifiers(b *testing.B) {
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+	buf := make([]byte, 0, 128)
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = AppendStrftime(buf[:0], "%-d/%-m/%Y %_H:%0M %^a %#b %:z %Od %*d %10A", testTime, DefaultLocale)
+	}
+}
+
+func BenchmarkFormatTo(b *testing.B) {
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = FormatTo(io.Discard, "%Y-%m-%d %H:%M:%S", testTime, DefaultLocale)
+	}
+}
+
+func TestStrftime_WeekNumbers(t *testing.T) {
+	// Expected values taken from glibc date(1)
+	tests := []struct {
+		date string
+		u, w string
+		v    string
+	}{
+		{"2023-01-01", "01", "00", "52"},
+		{"2024-01-01", "00", "01", "01"},
+		{"2024-12-31", "52", "53", "01"},
+		{"2025-01-01", "00", "00", "01"},
+		{"2025-01-05", "01", "00", "01"},
+		{"2025-01-06", "01", "01", "02"},
+		{"2026-01-04", "01", "00", "01"},
+		{"2027-01-01", "00", "00", "53"},
+		{"2027-12-31", "52", "52", "52"},
+		{"2028-12-31", "53", "52", "52"},
+	}
+
+	for _, tt := range tests {
+		testTime, _ := time.Parse("2006-01-02", tt.date)
+		formatted := Strftime("%U %W %V", testTime)
+		expected := tt.u + " " + tt.w + " " + tt.v
+		if formatted != expected {
+			t.Errorf("Week numbers for %s: got [%s], expected [%s]", tt.date, formatted, expected)
+		}
+	}
+
+	testTime, _ := time.Parse("2006-01-02", "2025-01-05")
+	if formatted := Strftime("%-U %_W", testTime); formatted != "1  0" {
+		t.Errorf("Week number modifiers: got [%s], expected [1  0]", formatted)
+	}
+}
+
+func TestStrftime_FractionalSeconds(t *testing.T) {
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 123456789, time.UTC)
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%f", "123456"},
+		{"%L", "123"},
+		{"%N", "123456789"},
+		{"%3N", "123"},
+		{"%6N", "123456"},
+		{"%9N", "123456789"},
+		{"%1N", "1"},
+		{"%12N", "123456789000"},
+		{"%6L", "123456"},
+		{"%H:%M:%S.%L", "09:05:07.123"},
+	}
+
+	for _, tt := range tests {
+		formatted := Strftime(tt.format, testTime)
+		if formatted != tt.expected {
+			t.Errorf("For format [%s]: got [%s], expected [%s]", tt.format, formatted, tt.expected)
+		}
+	}
+
+	// Fractions are truncated, never rounded up
+	testTime = time.Date(2025, time.February, 3, 9, 5, 7, 999999999, time.UTC)
+	if formatted := Strftime("%S.%3N", testTime); formatted != "07.999" {
+		t.Errorf("Fraction truncation: got [%s], expected [07.999]", formatted)
+	}
+	testTime = time.Date(2025, time.February, 3, 9, 5, 7, 1000, time.UTC)
+	if formatted := Strftime("%f", testTime); formatted != "000001" {
+		t.Errorf("Fraction zero padding: got [%s], expected [000001]", formatted)
+	}
+}
+
+func TestStrftime_GNUFlagsAndWidth(t *testing.T) {
+	// Expected values taken from GNU date(1)
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%1d", "3"},
+		{"%3d", "003"},
+		{"%_3d", "  3"},
+		{"%-3d", "3"},
+		{"%10A", "    Monday"},
+		{"%010A", "0000Monday"},
+		{"%^A", "MONDAY"},
+		{"%#A", "MONDAY"},
+		{"%#p", "am"},
+		{"%^10B", "  FEBRUARY"},
+		{"%#Z", "utc"},
+		{"%5e", "    3"},
+		{"%0e", "03"},
+		{"%-e", "3"},
+		{"%_k", " 9"},
+		{"%-k", "9"},
+		{"%0l", "09"},
+		{"%012s", "001738573507"},
+		{"%3u", "001"},
+		{"%_3u", "  1"},
+		{"%^c", "MON FEB 3 09:05:07 2025"},
+		{"%#b", "FEB"},
+		{"%^-10a", "MON"},
+		{"%-_5d", "    3"},
+		{"%_-5d", "3"},
+		{"%5p", "   AM"},
+		{"%#5p", "   am"},
+		{"%^h", "FEB"},
+		{"%10n", "         \n"},
+		{"%3j", "034"},
+		{"%1j", "34"},
+		{"%4y", "0025"},
+		{"%^#a", "MON"},
+		{"%#^a", "MON"},
+		{"%^12r", " 09:05:07 AM"},
+		{"%12D", "    02/03/25"},
+		{"%5%", "    %"},
+	}
+
+	for _, tt := range tests {
+		formatted := Strftime(tt.format, testTime)
+		if formatted != tt.expected {
+			t.Errorf("For format [%s]: got [%s], expected [%s]", tt.format, formatted, tt.expected)
+		}
+	}
+}
+
+func TestStrftime_CaseFlagsUnicode(t *testing.T) {
+	testTime := time.Date(2025, time.March, 3, 15, 5, 7, 0, time.UTC)
+	customLocale := &Locale{
+		WeekdaysFull:   []string{"Κυριακή", "Δευτέρα", "Τρίτη", "Τετάρτη", "Πέμπτη", "Παρασκευή", "Σάββατο"},
+		WeekdaysAbbrev: DefaultLocale.WeekdaysAbbrev,
+		MonthsFull:     []string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		MonthsAbbrev:   DefaultLocale.MonthsAbbrev,
+		AM:             "上午",
+		PM:             "下午",
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%^B", "MÄRZ"},
+		{"%#B", "MÄRZ"},
+		{"%^A", "ΔΕΥΤΈΡΑ"},
+		{"%8B", "    März"},
+		{"%_8A", " Δευτέρα"},
+		{"%^p", "下午"},
+		{"%4p", "  下午"},
+	}
+
+	for _, tt := range tests {
+		formatted := StrftimeL(tt.format, testTime, customLocale)
+		if formatted != tt.expected {
+			t.Errorf("For format [%s]: got [%s], expected [%s]", tt.format, formatted, tt.expected)
+		}
+	}
+
+	allCaps := &Locale{
+		WeekdaysFull:   DefaultLocale.WeekdaysFull,
+		WeekdaysAbbrev: DefaultLocale.WeekdaysAbbrev,
+		MonthsFull:     []string{"JANUAR", "FEBRUAR", "MÄRZ", "APRIL", "MAI", "JUNI", "JULI", "AUGUST", "SEPTEMBER", "OKTOBER", "NOVEMBER", "DEZEMBER"},
+		MonthsAbbrev:   DefaultLocale.MonthsAbbrev,
+		AM:             DefaultLocale.AM,
+		PM:             DefaultLocale.PM,
+	}
+	if formatted := StrftimeL("%#B", testTime, allCaps); formatted != "märz" {
+		t.Errorf("Opposite case of an uppercase name: got [%s], expected [märz]", formatted)
+	}
+}
+
+func TestStrftime_OffsetColons(t *testing.T) {
+	tests := []struct {
+		offset   int
+		format   string
+		expected string
+	}{
+		{5*3600 + 30*60, "%z", "+0530"},
+		{5*3600 + 30*60, "%:z", "+05:30"},
+		{5*3600 + 30*60, "%::z", "+05:30:00"},
+		{5*3600 + 30*60, "%:::z", "+05:30"},
+		{-4 * 3600, "%:::z", "-04"},
+		{-(17*60 + 30), "%z", "-0017"}, // Local mean time offsets have seconds
+		{-(17*60 + 30), "%::z", "-00:17:30"},
+		{-(17*60 + 30), "%:::z", "-00:17:30"},
+		{0, "%z", "+0000"},
+		{0, "%:z", "+00:00"},
+		{0, "%:::z", "+00"},
+		{0, "%^8:z", "  +00:00"},
+		{0, "%::::z", "z"},
+		{0, "%:Y", "Y"},
+	}
+
+	for _, tt := range tests {
+		testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.FixedZone("", tt.offset))
+		formatted := Strftime(tt.format, testTime)
+		if formatted != tt.expected {
+			t.Errorf("For format [%s] and offset %d: got [%s], expected [%s]", tt.format, tt.offset, formatted, tt.expected)
+		}
+	}
+}
+
+func TestStrftimeWith_ZuluUTC(t *testing.T) {
+	utc := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+	formatted := StrftimeWith("%Y-%m-%dT%H:%M:%S%:z", utc, WithZuluUTC())
+	expected := "2025-02-03T09:05:07Z"
+	if formatted != expected {
+		t.Errorf("Zulu UTC failed, got [%s], expected [%s]", formatted, expected)
+	}
+
+	ist := utc.In(time.FixedZone("IST", 5*3600+30*60))
+	formatted = StrftimeWith("%H:%M%:z %z %:::z", ist, WithZuluUTC())
+	expected = "14:35+05:30 +0530 +05:30"
+	if formatted != expected {
+		t.Errorf("Zulu UTC with non-zero offset failed, got [%s], expected [%s]", formatted, expected)
+	}
+
+	f := MustCompile("%r%z", WithZuluUTC())
+	if formatted := f.Format(utc); formatted != "09:05:07 AMZ" {
+		t.Errorf("Compiled Zulu UTC failed, got [%s], expected [09:05:07 AMZ]", formatted)
+	}
+}
+
+func TestStrftime_LocaleComposites(t *testing.T) {
+	testTime := time.Date(2025, time.February, 25, 15, 30, 45, 0, time.UTC)
+	germanLocale := &Locale{
+		WeekdaysFull:   []string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		WeekdaysAbbrev: []string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		MonthsFull:     []string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		MonthsAbbrev:   []string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		AM:             "AM",
+		PM:             "PM",
+		DateTimeFormat: "%A, %-d. %B %Y %H:%M:%S",
+		DateFormat:     "%d.%m.%Y",
+		TimeFormat:     "%H:%M:%S",
+		TimeFormat12:   "%I:%M:%S %p",
+		DateCmdFormat:  "%a %-d. %b %H:%M:%S %Z %Y",
+	}
+	chineseLocale := &Locale{
+		WeekdaysFull:   []string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		WeekdaysAbbrev: []string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		MonthsFull:     []string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		MonthsAbbrev:   []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		AM:             "上午",
+		PM:             "下午",
+		DateTimeFormat: "%Y年%-m月%-d日 %A %H:%M:%S",
+		DateFormat:     "%Y年%m月%d日",
+		TimeFormat12:   "%p%I时%M分%S秒",
+	}
+
+	tests := []struct {
+		loc      *Locale
+		format   string
+		expected string
+	}{
+		{DefaultLocale, "%c", "Tue Feb 25 15:30:45 2025"},
+		{DefaultLocale, "%x %X", "02/25/25 15:30:45"},
+		{DefaultLocale, "%r", "03:30:45 PM"},
+		{DefaultLocale, "%+", "Tue Feb 25 15:30:45 UTC 2025"},
+		{germanLocale, "%c", "Dienstag, 25. Februar 2025 15:30:45"},
+		{germanLocale, "%x", "25.02.2025"},
+		{germanLocale, "%X", "15:30:45"},
+		{germanLocale, "%+", "Di 25. Feb 15:30:45 UTC 2025"},
+		{germanLocale, "%D", "02/25/25"}, // %D is always %m/%d/%y
+		{chineseLocale, "%c", "2025年2月25日 星期二 15:30:45"},
+		{chineseLocale, "%x", "2025年02月25日"},
+		{chineseLocale, "%X", "15:30:45"}, // Unset patterns use the POSIX default
+		{chineseLocale, "%r", "下午03时30分45秒"},
+		{germanLocale, "%^c", "DIENSTAG, 25. FEBRUAR 2025 15:30:45"},
+		{germanLocale, "%12x", "  25.02.2025"},
+	}
+
+	for _, tt := range tests {
+		formatted := StrftimeL(tt.format, testTime, tt.loc)
+		if formatted != tt.expected {
+			t.Errorf("For format [%s]: got [%s], expected [%s]", tt.format, formatted, tt.expected)
+		}
+		f := MustCompile(tt.format, WithLocale(tt.loc))
+		if formatted := f.Format(testTime); formatted != tt.expected {
+			t.Errorf("For compiled format [%s]: got [%s], expected [%s]", tt.format, formatted, tt.expected)
+		}
+	}
+}
+
+func TestStrftime_RecursiveLocaleComposite(t *testing.T) {
+	testTime := time.Date(2025, time.February, 25, 15, 30, 45, 0, time.UTC)
+	loopLocale := *DefaultLocale
+	loopLocale.DateFormat = "[%x]"
+	loopLocale.DateTimeFormat = "%x %X"
+
+	// Expansion stops once composites are nested too deeply
+	expected := "[[[]]] 15:30:45"
+	if formatted := StrftimeL("%c", testTime, &loopLocale); formatted != expected {
+		t.Errorf("Recursive composite: got [%s], expected [%s]", formatted, expected)
+	}
+	if formatted := MustCompile("%c", WithLocale(&loopLocale)).Format(testTime); formatted != expected {
+		t.Errorf("Compiled recursive composite: got [%s], expected [%s]", formatted, expected)
+	}
+}
+
+func TestStrftime_CalendarPosition(t *testing.T) {
+	monday := *DefaultLocale
+	monday.FirstWeekday = time.Monday
+
+	tests := []struct {
+		date     string
+		locale   *Locale
+		format   string
+		expected string
+	}{
+		{"2025-02-20", DefaultLocale, "%Y-Q%q H%J", "2025-Q1 H1"},
+		{"2025-11-15", DefaultLocale, "%Y-Q%q H%J", "2025-Q4 H2"},
+		{"2025-02-20", DefaultLocale, "week %K of %B", "week 4 of February"},
+		{"2025-02-20", &monday, "week %K of %B", "week 4 of February"},
+		{"2025-02-02", DefaultLocale, "%K", "2"},
+		{"2025-02-02", &monday, "%K", "1"},
+		{"2025-06-30", DefaultLocale, "%K", "5"},
+		{"2025-06-30", &monday, "%K", "6"},
+		{"2025-02-20", DefaultLocale, "%i", "51"},
+		{"2025-01-01", DefaultLocale, "%i %-i %_3i", "01 1   1"},
+		{"2024-03-31", DefaultLocale, "%i", "91"},
+		{"2024-12-31", DefaultLocale, "%i", "92"},
+		{"2025-11-15", DefaultLocale, "%i", "46"},
+		{"2025-02-20", DefaultLocale, "%2q %02J %*K week", "01 01 4th week"},
+	}
+
+	for _, tt := range tests {
+		testTime, _ := time.Parse("2006-01-02", tt.date)
+		if formatted := StrftimeL(tt.format, testTime, tt.locale); formatted != tt.expected {
+			t.Errorf("StrftimeL(%q) for %s: got [%s], expected [%s]", tt.format, tt.date, formatted, tt.expected)
+		}
+	}
+}
+
+func TestStrftime_CalendarPositionRegistered(t *testing.T) {
+	// Bindings made before the calendar position specifiers existed keep working
+	testTime := time.Date(2025, time.February, 20, 9, 0, 0, 0, time.UTC)
+	set := NewSpecifierSet()
+	for _, r := range []rune{'q', 'J', 'K', 'i'} {
+		if err := set.Register(r, func(dst []byte, _ time.Time, _ *Locale, _ Modifiers) []byte {
+			return append(dst, "custom"...)
+		}, buildParse); err != nil {
+			t.Fatalf("Register(%q) returned error: %v", r, err)
+		}
+	}
+
+	if formatted := StrftimeWith("%q %J %K %i", testTime, WithSpecifiers(set)); formatted != "custom custom custom custom" {
+		t.Errorf("StrftimeWith with registered calendar letters: got [%s], expected [custom custom custom custom]", formatted)
+	}
+	if formatted := StrftimeWith("%q %J %K %i", testTime); formatted != "1 1 4 51" {
+		t.Errorf("StrftimeWith without set: got [%s], expected [1 1 4 51]", formatted)
+	}
+	parsed, err := ParseWith("%q", "483000", WithSpecifiers(set))
+	if err != nil {
+		t.Fatalf("ParseWith with registered %%q returned error: %v", err)
+	}
+	if expected := time.Unix(483000*3600, 0).UTC(); !parsed.Equal(expected) {
+		t.Errorf("ParseWith with registered %%q: got [%v], expected [%v]", parsed, expected)
+	}
+}
+
+func TestStrftime_NegativeAndExpandedYears(t *testing.T) {
+	date := func(year int) time.Time {
+		return time.Date(year, time.March, 15, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		t        time.Time
+		format   string
+		opts     []Option
+		expected string
+	}{
+		{date(-45), "%Y", nil, "-0045"},
+		{date(-45), "%C %y", nil, "-01 55"},
+		{date(-45), "%-Y|%_Y|%6Y", nil, "-45|  -45|-000045"},
+		{date(-45), "%F", nil, "-0045-03-15"},
+		{date(-100), "%C %y", nil, "-01 00"},
+		{date(-101), "%C %y", nil, "-02 99"},
+		{date(0), "%Y %C %y", nil, "0000 00 00"},
+		{date(7), "%Y %C %y", nil, "0007 00 07"},
+		{date(12345), "%Y %C %y", nil, "12345 123 45"},
+		{date(12345), "%Y", []Option{WithExpandedYears(6)}, "+012345"},
+		{date(-44), "%Y", []Option{WithExpandedYears(6)}, "-000044"},
+		{date(-44), "%Y", []Option{WithExpandedYears(4)}, "-0044"},
+		{date(2025), "%F", []Option{WithExpandedYears(6)}, "+002025-03-15"},
+		{date(2025), "%G", []Option{WithExpandedYears(5)}, "+02025"},
+		{date(-45), "%G %g", nil, "-0045 55"},
+	}
+
+	for _, tt := range tests {
+		formatted := StrftimeWith(tt.format, tt.t, tt.opts...)
+		if formatted != tt.expected {
+			t.Errorf("StrftimeWith(%q) for year %d: got [%s], expected [%s]", tt.format, tt.t.Year(), formatted, tt.expected)
+		}
+		f := MustCompile(tt.format, tt.opts...)
+		if formatted := f.Format(tt.t); formatted != tt.expected {
+			t.Errorf("Compiled %q for year %d: got [%s], expected [%s]", tt.format, tt.t.Year(), formatted, tt.expected)
+		}
+	}
+}
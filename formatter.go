@@ -0,0 +1,144 @@
+package strftime
+
+import (
+	"io"
+	"time"
+)
+
+// Formatter is a precompiled format string.
+// It is immutable once compiled and safe for concurrent use by multiple goroutines.
+type Formatter struct {
+	format string
+	opts   options
+	ops    []op
+	size   int // Estimated output length, used to size the buffer
+}
+
+// Compile parses format once into a list of instructions that can be replayed by Format.
+// The output of the Formatter is identical to that of StrftimeL with the same format and locale.
+// With WithUnknownSpecifierPolicy(UnknownSpecifierError), an unknown or incomplete specifier
+// in the format or in a locale pattern it expands to is reported as a *FormatError.
+func Compile(format string, opts ...Option) (*Formatter, error) {
+	o := newOptions(opts)
+	f := &Formatter{
+		format: format,
+		opts:   o,
+	}
+	ops, err := compileOps(nil, format, &f.opts)
+	if err != nil {
+		return nil, err
+	}
+	f.ops = ops
+	for _, op := range f.ops {
+		f.size += op.sizeHint()
+	}
+	return f, nil
+}
+
+// MustCompile is like Compile but panics if the format cannot be compiled
+func MustCompile(format string, opts ...Option) *Formatter {
+	f, err := Compile(format, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// compileOps appends the ops for format to ops.
+// Adjacent literals are merged and composite specifiers are expanded in place.
+func compileOps(ops []op, format string, opts *options) ([]op, error) {
+	i := 0
+	for i < len(format) {
+		if format[i] != '%' {
+			start := i
+			for i < len(format) && format[i] != '%' {
+				i++
+			}
+			ops = appendLiteralOp(ops, format[start:i])
+			continue
+		}
+
+		o, next := scanDirective(format, i, opts)
+		if o.kind == opUnknown {
+			var err error
+			if o, err = opts.unknown.resolve(o, format, i, next); err != nil {
+				return nil, err
+			}
+		}
+		switch {
+		case o.kind == opLiteral && o.plain():
+			ops = appendLiteralOp(ops, o.text)
+		case o.kind == opComposite && opts.depth >= maxCompositeDepth:
+			// Drop composites nested too deeply, as formatting without compiling does
+		case o.kind == opComposite:
+			nested := *opts
+			nested.depth++
+			var err error
+			if o.plain() {
+				if ops, err = compileOps(ops, o.text, &nested); err != nil {
+					return nil, err
+				}
+				break
+			}
+			// Case conversion and width apply to the whole expansion
+			if o.sub, err = compileOps(nil, o.text, &nested); err != nil {
+				return nil, err
+			}
+			ops = append(ops, o)
+		default:
+			ops = append(ops, o)
+		}
+		i = next
+	}
+	return ops, nil
+}
+
+// appendLiteralOp appends text to ops, merging it into a trailing plain literal if there is one
+func appendLiteralOp(ops []op, text string) []op {
+	if n := len(ops); n > 0 && ops[n-1].kind == opLiteral && ops[n-1].plain() {
+		ops[n-1].text += text
+		return ops
+	}
+	return append(ops, op{kind: opLiteral, text: text})
+}
+
+// sizeHint estimates the number of bytes the op produces
+func (o op) sizeHint() int {
+	switch o.kind {
+	case opLiteral:
+		return max(len(o.text), o.width)
+	case opNumber, opFraction:
+		return max(o.digits, 4)
+	case opLayout:
+		return max(len(o.text)+4, o.width)
+	default:
+		return max(12, o.width)
+	}
+}
+
+// Format formats t according to the compiled format
+func (f *Formatter) Format(t time.Time) string {
+	return string(f.AppendFormat(make([]byte, 0, f.size), t))
+}
+
+// AppendFormat formats t according to the compiled format and appends the result to dst.
+// No allocations are made as long as dst has enough capacity for the output.
+func (f *Formatter) AppendFormat(dst []byte, t time.Time) []byte {
+	for k := range f.ops {
+		dst = f.ops[k].appendTo(dst, t, &f.opts)
+	}
+	return dst
+}
+
+// FormatTo formats t according to the compiled format and writes the result to w
+func (f *Formatter) FormatTo(w io.Writer, t time.Time) (int, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	*buf = f.AppendFormat((*buf)[:0], t)
+	return w.Write(*buf)
+}
+
+// Pattern returns the format string the Formatter was compiled from
+func (f *Formatter) Pattern() string {
+	return f.format
+}
@@ -0,0 +1,129 @@
+package strftime
+
+import (
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCompile_MatchesStrftimeL(t *testing.T) {
+	chineseLocale := &Locale{
+		WeekdaysFull:   []string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		WeekdaysAbbrev: []string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		MonthsFull:     []string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		MonthsAbbrev:   []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		AM:             "上午",
+		PM:             "下午",
+	}
+	times := []time.Time{
+		time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC),
+		time.Date(2024, time.December, 30, 0, 0, 0, 0, time.FixedZone("EST", -5*3600)),
+		time.Date(1999, time.July, 4, 23, 59, 59, 0, time.FixedZone("IST", 5*3600+1800)),
+	}
+	formats := []string{
+		"",
+		"plain text",
+		"%Y-%m-%d %H:%M:%S",
+		"%A %a %B %b %h %C %c %D %d %e %F %G %g %H %I %j %k %l %M %m %n %p",
+		"%R %r %S %s %T %t %U %u %V %v %W %w %X %x %Y %y %Z %z %+ %%",
+		"%-d/%-m/%-Y %-H:%-M:%-S %-j %-C %-G",
+		"%_d/%_m/%_Y %_H:%_M:%_S %_j %_e",
+		"%0d/%0m/%0Y %EY %OH %E%%Y",
+		"%Q %-Q unknown",
+		"%^a %#p %10A %010B %^-10b %5e %-k %_l %012s %3u",
+		"%^14r %#c %^_12v %5% %3n|%-4t|",
+		"%%% %%%%",
+		"trailing %",
+		"trailing %-",
+		"trailing %E",
+	}
+
+	for _, loc := range []*Locale{nil, DefaultLocale, chineseLocale} {
+		for _, format := range formats {
+			f, err := Compile(format, WithLocale(loc))
+			if err != nil {
+				t.Fatalf("Compile(%q) returned error: %v", format, err)
+			}
+			for _, tm := range times {
+				got := f.Format(tm)
+				expected := StrftimeL(format, tm, loc)
+				if got != expected {
+					t.Errorf("Compiled format [%s] with time [%v]: got [%s], expected [%s]", format, tm, got, expected)
+				}
+			}
+		}
+	}
+}
+
+func TestCompile_MergesLiterals(t *testing.T) {
+	f := MustCompile("a%%b%nc%r")
+	// "a%b\nc" is a single literal, %r expands to %I ":" %M ":" %S " " %p
+	if len(f.ops) != 8 {
+		t.Fatalf("Expected 8 ops, got %d: %+v", len(f.ops), f.ops)
+	}
+	if f.ops[0].kind != opLiteral || f.ops[0].text != "a%b\nc" {
+		t.Errorf("Expected merged literal [a%%b\\nc], got %+v", f.ops[0])
+	}
+	if f.Pattern() != "a%%b%nc%r" {
+		t.Errorf("Pattern returned [%s]", f.Pattern())
+	}
+}
+
+func TestFormatter_Concurrent(t *testing.T) {
+	f := MustCompile("%Y-%m-%dT%H:%M:%S %A %p")
+	base := time.Date(2025, time.February, 25, 15, 30, 45, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for n := 0; n < 200; n++ {
+				tm := base.Add(time.Duration(g*200+n) * time.Hour)
+				if got, expected := f.Format(tm), Strftime(f.Pattern(), tm); got != expected {
+					t.Errorf("Concurrent format: got [%s], expected [%s]", got, expected)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestFormatter_AppendFormatZeroAllocs(t *testing.T) {
+	f := MustCompile("%Y-%m-%dT%H:%M:%S %j %e %a %p")
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+	buf := make([]byte, 0, 128)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = f.AppendFormat(buf[:0], testTime)
+	})
+	if allocs != 0 {
+		t.Errorf("Formatter.AppendFormat made %v allocations per call, expected 0", allocs)
+	}
+	allocs = testing.AllocsPerRun(100, func() {
+		_, _ = f.FormatTo(io.Discard, testTime)
+	})
+	if allocs != 0 {
+		t.Errorf("Formatter.FormatTo made %v allocations per call, expected 0", allocs)
+	}
+}
+
+func BenchmarkFormatter_Format(b *testing.B) {
+	f := MustCompile("%Y-%m-%d %H:%M:%S")
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+	b.ReportAllocs()
+	for b.Loop() {
+		f.Format(testTime)
+	}
+}
+
+func BenchmarkFormatter_AppendFormat(b *testing.B) {
+	f := MustCompile("%Y-%m-%d %H:%M:%S")
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = f.AppendFormat(buf[:0], testTime)
+	}
+}
@@ -0,0 +1,299 @@
+package strftime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ToGoLayout converts a strftime format to the equivalent Go reference layout for time.Time.Format
+// and time.Parse, using the names of DefaultLocale. Composite specifiers such as %F and %T are expanded.
+// Specifiers without a Go equivalent, such as %U or %s, and literal text that Go would read as
+// part of the layout, such as a "1" after %d, are reported as errors.
+func ToGoLayout(format string) (string, error) {
+	opts := options{locale: DefaultLocale, unknown: UnknownSpecifierError}
+	layout, err := appendGoLayout(nil, format, &opts)
+	if err != nil {
+		return "", err
+	}
+
+	// Go has no way to quote literal text, so check that it wasn't taken for layout elements
+	for _, t := range layoutProbes {
+		if t.Format(string(layout)) != string(appendFormat(nil, format, t, &opts)) {
+			return "", fmt.Errorf("strftime: literal text in %q would be read as part of the Go layout %q", format, layout)
+		}
+	}
+	return string(layout), nil
+}
+
+// layoutProbes are times whose fields all differ from each other and from Go's reference time
+var layoutProbes = []time.Time{
+	time.Date(2017, time.November, 23, 21, 48, 39, 123456789, time.FixedZone("ABC", 3*3600+30*60)),
+	time.Date(1999, time.March, 7, 8, 9, 10, 5000, time.UTC),
+}
+
+// appendGoLayout appends the Go layout for format to dst
+func appendGoLayout(dst []byte, format string, opts *options) ([]byte, error) {
+	i := 0
+	for i < len(format) {
+		if format[i] != '%' {
+			start := i
+			for i < len(format) && format[i] != '%' {
+				i++
+			}
+			dst = append(dst, format[start:i]...)
+			continue
+		}
+
+		o, next := scanDirective(format, i, opts)
+		switch {
+		case o.kind == opUnknown:
+			_, err := opts.unknown.resolve(o, format, i, next)
+			return nil, err
+		case o.kind == opComposite && o.plain() && opts.depth < maxCompositeDepth:
+			nested := *opts
+			nested.depth++
+			var err error
+			if dst, err = appendGoLayout(dst, o.text, &nested); err != nil {
+				return nil, err
+			}
+		case o.kind == opFraction && o.plain():
+			// Go layouts write fractional seconds together with the separator before them
+			if n := len(dst); n == 0 || (dst[n-1] != '.' && dst[n-1] != ',') {
+				return nil, fmt.Errorf("strftime: %s at offset %d in %q must follow '.' or ',' in a Go layout", format[i:next], i, format)
+			}
+			dst = append(dst, strings.Repeat("0", o.digits)...)
+		default:
+			layout := o.goLayout()
+			if layout == "" {
+				return nil, fmt.Errorf("strftime: %s at offset %d in %q has no Go layout equivalent", format[i:next], i, format)
+			}
+			dst = append(dst, layout...)
+		}
+		i = next
+	}
+	return dst, nil
+}
+
+// goLayout returns the Go layout element that writes the same text as the op, or "" if there is none
+func (o op) goLayout() string {
+	if o.width > 0 || o.alt || o.ordinal || o.sign {
+		return ""
+	}
+	if o.textCase != caseNone {
+		// Only the AM/PM marker, which is already uppercase, has a lowercase form in Go
+		if o.kind != opName || o.names != nameAMPM {
+			return ""
+		}
+		if o.textCase == caseSwap {
+			return "pm"
+		}
+		return "PM"
+	}
+
+	switch o.kind {
+	case opLiteral:
+		return o.text
+	case opLayout:
+		return o.text
+	case opName:
+		switch o.names {
+		case nameWeekdayFull:
+			return "Monday"
+		case nameWeekdayAbbrev:
+			return "Mon"
+		case nameMonthFull:
+			return "January"
+		case nameMonthAbbrev:
+			return "Jan"
+		case nameAMPM:
+			return "PM"
+		}
+	case opOffset:
+		layouts := [...]string{"-0700", "-07:00", "-07:00:00"}
+		if o.digits >= len(layouts) {
+			return ""
+		}
+		if o.zulu {
+			return "Z" + layouts[o.digits][1:]
+		}
+		return layouts[o.digits]
+	case opNumber:
+		type number struct {
+			field  field
+			digits int
+			pad    byte
+		}
+		switch (number{o.field, o.digits, o.pad}) {
+		case number{fieldYear, 4, '0'}:
+			return "2006"
+		case number{fieldYear2, 2, '0'}:
+			return "06"
+		case number{fieldMonth, 2, '0'}:
+			return "01"
+		case number{fieldMonth, 0, '0'}:
+			return "1"
+		case number{fieldDay, 2, '0'}:
+			return "02"
+		case number{fieldDay, 2, ' '}:
+			return "_2"
+		case number{fieldDay, 0, '0'}, number{fieldDay, 0, ' '}:
+			return "2"
+		case number{fieldYearDay, 3, '0'}:
+			return "002"
+		case number{fieldYearDay, 3, ' '}:
+			return "__2"
+		case number{fieldHour, 2, '0'}:
+			return "15"
+		case number{fieldHour12, 2, '0'}:
+			return "03"
+		case number{fieldHour12, 0, '0'}, number{fieldHour12, 0, ' '}:
+			return "3"
+		case number{fieldMinute, 2, '0'}:
+			return "04"
+		case number{fieldMinute, 0, '0'}:
+			return "4"
+		case number{fieldSecond, 2, '0'}:
+			return "05"
+		case number{fieldSecond, 0, '0'}:
+			return "5"
+		}
+	}
+	return ""
+}
+
+// FromGoLayout converts a Go reference layout to the equivalent strftime format.
+// Layout elements without a strftime equivalent, such as the trimmed fractions ".999"
+// or the offset forms "-07" and "-070000", are reported as errors.
+func FromGoLayout(layout string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(layout); {
+		spec, n := goLayoutElement(layout[i:])
+		switch {
+		case n == 0:
+			if layout[i] == '%' {
+				b.WriteString("%%")
+			} else {
+				b.WriteByte(layout[i])
+			}
+			i++
+			continue
+		case spec == "":
+			return "", fmt.Errorf("strftime: Go layout element %q at offset %d in %q has no strftime equivalent", layout[i:i+n], i, layout)
+		}
+		b.WriteString(spec)
+		i += n
+	}
+	return b.String(), nil
+}
+
+// goLayoutElement reads the Go layout element at the start of layout, following the rules of the time package.
+// It returns the strftime equivalent and the length of the element, with n == 0 if layout
+// doesn't start with an element and spec == "" if the element has no equivalent.
+func goLayoutElement(layout string) (spec string, n int) {
+	// startsWithLowerCase reports whether the text after a name continues a word, as in "Month"
+	startsWithLowerCase := func(s string) bool {
+		return s != "" && 'a' <= s[0] && s[0] <= 'z'
+	}
+
+	switch layout[0] {
+	case 'J':
+		if strings.HasPrefix(layout, "January") {
+			return "%B", 7
+		}
+		if strings.HasPrefix(layout, "Jan") && !startsWithLowerCase(layout[3:]) {
+			return "%b", 3
+		}
+	case 'M':
+		if strings.HasPrefix(layout, "Monday") {
+			return "%A", 6
+		}
+		if strings.HasPrefix(layout, "Mon") && !startsWithLowerCase(layout[3:]) {
+			return "%a", 3
+		}
+		if strings.HasPrefix(layout, "MST") {
+			return "%Z", 3
+		}
+	case '0':
+		if strings.HasPrefix(layout, "002") {
+			return "%j", 3
+		}
+		if len(layout) >= 2 && '1' <= layout[1] && layout[1] <= '6' {
+			return [...]string{"%m", "%d", "%I", "%M", "%S", "%y"}[layout[1]-'1'], 2
+		}
+	case '1':
+		if strings.HasPrefix(layout, "15") {
+			return "%H", 2
+		}
+		return "%-m", 1
+	case '2':
+		if strings.HasPrefix(layout, "2006") {
+			return "%Y", 4
+		}
+		return "%-d", 1
+	case '_':
+		if strings.HasPrefix(layout, "_2") && !strings.HasPrefix(layout, "_2006") {
+			return "%e", 2
+		}
+		if strings.HasPrefix(layout, "__2") {
+			return "%_j", 3
+		}
+	case '3':
+		return "%-I", 1
+	case '4':
+		return "%-M", 1
+	case '5':
+		return "%-S", 1
+	case 'P':
+		if strings.HasPrefix(layout, "PM") {
+			return "%p", 2
+		}
+	case 'p':
+		if strings.HasPrefix(layout, "pm") {
+			return "%#p", 2
+		}
+	case '-', 'Z':
+		// Longest forms first, as the time package reads them
+		forms := [...]struct{ layout, spec string }{
+			{"070000", ""},
+			{"07:00:00", "%::z"},
+			{"0700", "%z"},
+			{"07:00", "%:z"},
+			{"07", ""},
+		}
+		for _, f := range forms {
+			if strings.HasPrefix(layout[1:], f.layout) {
+				if f.spec != "" && layout[0] == 'Z' {
+					return f.spec[:len(f.spec)-1] + "Ez", len(f.layout) + 1
+				}
+				return f.spec, len(f.layout) + 1
+			}
+		}
+	case '.', ',':
+		// Fractional seconds: the separator followed by a run of 0s or 9s that isn't followed by a digit
+		if len(layout) >= 2 && (layout[1] == '0' || layout[1] == '9') {
+			j := 1
+			for j < len(layout) && layout[j] == layout[1] {
+				j++
+			}
+			if j < len(layout) && '0' <= layout[j] && layout[j] <= '9' {
+				break
+			}
+			if layout[1] == '9' {
+				return "", j
+			}
+			switch digits := j - 1; digits {
+			case 3:
+				return layout[:1] + "%L", j
+			case 6:
+				return layout[:1] + "%f", j
+			case 9:
+				return layout[:1] + "%N", j
+			default:
+				return layout[:1] + "%" + strconv.Itoa(digits) + "N", j
+			}
+		}
+	}
+	return "", 0
+}
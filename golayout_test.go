@@ -0,0 +1,113 @@
+package strftime
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var layoutTestTimes = []time.Time{
+	time.Date(2025, time.February, 3, 9, 5, 7, 123456789, time.UTC),
+	time.Date(2024, time.December, 31, 23, 59, 59, 0, time.FixedZone("IST", 5*3600+1800)),
+	time.Date(1999, time.July, 14, 12, 0, 0, 5000, time.FixedZone("", -7*3600)),
+}
+
+func TestToGoLayout(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%Y-%m-%dT%H:%M:%S%:Ez", time.RFC3339},
+		{"%Y-%m-%dT%H:%M:%S.%N%:Ez", "2006-01-02T15:04:05.000000000Z07:00"},
+		{"%a, %d %b %Y %H:%M:%S %z", time.RFC1123Z},
+		{"%a, %d %b %Y %H:%M:%S %Z", time.RFC1123},
+		{"%a %b %e %H:%M:%S %Y", time.ANSIC},
+		{"%d %b %y %H:%M %Z", time.RFC822},
+		{"%-I:%M%p", time.Kitchen},
+		{"%F %T", time.DateTime},
+		{"%D", "01/02/06"},
+		{"%A %B %-d %-I:%-M:%-S %#p", "Monday January 2 3:4:5 pm"},
+		{"%j %_j", "002 __2"},
+		{"%H:%M:%S,%L", "15:04:05,000"},
+		{"%S.%3N", "05.000"},
+		{"%::z %Ez %::Ez", "-07:00:00 Z0700 Z07:00:00"},
+		{"%% of %T", "% of 15:04:05"},
+		{"%c", "Mon Jan 2 15:04:05 2006"},
+	}
+
+	for _, tt := range tests {
+		layout, err := ToGoLayout(tt.format)
+		if err != nil {
+			t.Errorf("ToGoLayout(%q) returned error: %v", tt.format, err)
+			continue
+		}
+		if layout != tt.expected {
+			t.Errorf("ToGoLayout(%q): got [%s], expected [%s]", tt.format, layout, tt.expected)
+		}
+		for _, tm := range layoutTestTimes {
+			if got, want := tm.Format(layout), Strftime(tt.format, tm); got != want {
+				t.Errorf("Round trip of %q at %v: Go wrote [%s], expected [%s]", tt.format, tm, got, want)
+			}
+		}
+	}
+
+	invalid := []string{
+		"%j %U", "%W", "%s", "%C", "%u", "%k", "%l", "%G-W%V", "%^a", "%10A", "%*d",
+		"%:::z", "%f", "%Y%Q", "%",
+		"%d1",    // "1" would be read as the month
+		"Mon %d", // "Mon" would be read as the weekday
+	}
+	for _, format := range invalid {
+		if layout, err := ToGoLayout(format); err == nil {
+			t.Errorf("ToGoLayout(%q) expected error, but got [%s]", format, layout)
+		}
+	}
+
+	_, err := ToGoLayout("%Y-%U")
+	if err == nil || !strings.Contains(err.Error(), "%U") {
+		t.Errorf("ToGoLayout error should name the specifier, got %v", err)
+	}
+}
+
+func TestFromGoLayout(t *testing.T) {
+	tests := []struct {
+		layout   string
+		expected string
+	}{
+		{time.RFC3339, "%Y-%m-%dT%H:%M:%S%:Ez"},
+		{time.RFC3339Nano[:len(time.RFC3339Nano)-len(".999999999Z07:00")] + ".000000Z07:00", "%Y-%m-%dT%H:%M:%S.%f%:Ez"},
+		{time.RFC1123Z, "%a, %d %b %Y %H:%M:%S %z"},
+		{time.RFC850, "%A, %d-%b-%y %H:%M:%S %Z"},
+		{time.ANSIC, "%a %b %e %H:%M:%S %Y"},
+		{time.UnixDate, "%a %b %e %H:%M:%S %Z %Y"},
+		{time.Kitchen, "%-I:%M%p"},
+		{time.StampMilli, "%b %e %H:%M:%S.%L"},
+		{time.DateTime, "%Y-%m-%d %H:%M:%S"},
+		{"Monday January 2 3:4:5 pm 002 __2", "%A %B %-d %-I:%-M:%-S %#p %j %_j"},
+		{"15:04:05,0000", "%H:%M:%S,%4N"},
+		{"Month_2006 %", "Month_%Y %%"},
+		{"-07:00:00 Z0700", "%::z %Ez"},
+	}
+
+	for _, tt := range tests {
+		format, err := FromGoLayout(tt.layout)
+		if err != nil {
+			t.Errorf("FromGoLayout(%q) returned error: %v", tt.layout, err)
+			continue
+		}
+		if format != tt.expected {
+			t.Errorf("FromGoLayout(%q): got [%s], expected [%s]", tt.layout, format, tt.expected)
+		}
+		for _, tm := range layoutTestTimes {
+			if got, want := Strftime(format, tm), tm.Format(tt.layout); got != want {
+				t.Errorf("Round trip of %q at %v: got [%s], expected [%s]", tt.layout, tm, got, want)
+			}
+		}
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, "15:04:05.999", "-07", "Z07", "-070000"} {
+		if format, err := FromGoLayout(layout); err == nil {
+			t.Errorf("FromGoLayout(%q) expected error, but got [%s]", layout, format)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package strftime
+
+import (
+	"strconv"
+	"strings"
+)
+
+// momentTokens lists the format tokens of moment.js and Day.js, longer tokens first.
+// Tokens with an empty spec have no strftime equivalent and carry the reason instead.
+var momentTokens = []struct {
+	token, spec, reason string
+}{
+	// Localized formats, as the "en" locale writes them
+	{"LTS", "%-I:%M:%S %p", ""},
+	{"LT", "%-I:%M %p", ""},
+	{"LLLL", "%A, %B %-d, %Y %-I:%M %p", ""},
+	{"LLL", "%B %-d, %Y %-I:%M %p", ""},
+	{"LL", "%B %-d, %Y", ""},
+	{"L", "%m/%d/%Y", ""},
+	{"llll", "%a, %b %-d, %Y %-I:%M %p", ""},
+	{"lll", "%b %-d, %Y %-I:%M %p", ""},
+	{"ll", "%b %-d, %Y", ""},
+	{"l", "%-m/%-d/%Y", ""},
+
+	{"YYYYYY", "", "signed six-digit years have no strftime equivalent"},
+	{"YYYYY", "%05Y", ""},
+	{"YYYY", "%Y", ""},
+	{"YY", "%y", ""},
+	{"Y", "%-Y", ""},
+	{"Mo", "%*m", ""},
+	{"MMMM", "%B", ""},
+	{"MMM", "%b", ""},
+	{"MM", "%m", ""},
+	{"M", "%-m", ""},
+	{"Qo", "%*q", ""},
+	{"Q", "%q", ""},
+	{"DDDo", "%*j", ""},
+	{"DDDD", "%j", ""},
+	{"DDD", "%-j", ""},
+	{"Do", "%o", ""},
+	{"DD", "%d", ""},
+	{"D", "%-d", ""},
+	{"dddd", "%A", ""},
+	{"ddd", "%a", ""},
+	{"dd", "", "two-letter weekday names have no strftime equivalent"},
+	{"do", "%*w", ""},
+	{"d", "%w", ""},
+	{"E", "%u", ""},
+	{"e", "", "locale weekday numbers have no strftime equivalent"},
+	{"wo", "", "locale week numbers have no strftime equivalent"},
+	{"ww", "", "locale week numbers have no strftime equivalent"},
+	{"w", "", "locale week numbers have no strftime equivalent"},
+	{"Wo", "%*V", ""},
+	{"WW", "%V", ""},
+	{"W", "%-V", ""},
+	{"gggg", "", "locale week years have no strftime equivalent"},
+	{"gg", "", "locale week years have no strftime equivalent"},
+	{"GGGG", "%G", ""},
+	{"GG", "%g", ""},
+	{"A", "%p", ""},
+	{"a", "%#p", ""},
+	{"HH", "%H", ""},
+	{"H", "%-H", ""},
+	{"hh", "%I", ""},
+	{"h", "%-I", ""},
+	{"kk", "", "hours 1-24 have no strftime equivalent"},
+	{"k", "", "hours 1-24 have no strftime equivalent"},
+	{"mm", "%M", ""},
+	{"m", "%-M", ""},
+	{"ss", "%S", ""},
+	{"s", "%-S", ""},
+	{"ZZ", "%z", ""},
+	{"Z", "%:z", ""},
+	{"zz", "%Z", ""},
+	{"z", "%Z", ""},
+	{"X", "%s", ""},
+	{"x", "", "Unix milliseconds have no strftime equivalent"},
+	{"NNNNN", "", "era names have no strftime equivalent"},
+	{"NNNN", "", "era names have no strftime equivalent"},
+	{"NNN", "", "era names have no strftime equivalent"},
+	{"NN", "", "era names have no strftime equivalent"},
+	{"N", "", "era names have no strftime equivalent"},
+	{"yo", "", "era years have no strftime equivalent"},
+	{"y", "", "era years have no strftime equivalent"},
+}
+
+// FromMoment converts a moment.js or Day.js format to the equivalent strftime format.
+// Text in square brackets is literal, as are characters that aren't tokens. Ordinal tokens such as Do
+// use the '*' flag and the locale's ordinal rule, and the localized formats such as LL expand to the
+// patterns of moment's "en" locale.
+//
+// Tokens without a strftime equivalent, such as the locale week ww or Unix milliseconds x, are left out of
+// the result and listed in a *TranslationError. The rest of the format is still translated and returned with it.
+func FromMoment(pattern string) (string, error) {
+	t := translation{source: pattern}
+next:
+	for i := 0; i < len(pattern); {
+		if pattern[i] == '[' {
+			if end := strings.IndexByte(pattern[i:], ']'); end > 0 {
+				for k := i + 1; k < i+end; k++ {
+					writeLiteral(&t.out, pattern[k])
+				}
+				i += end + 1
+				continue
+			}
+		}
+
+		// Fractional seconds, with as many digits as there are letters
+		if pattern[i] == 'S' {
+			n := 1
+			for i+n < len(pattern) && pattern[i+n] == 'S' {
+				n++
+			}
+			if spec, reason := ldmlField('S', n); reason != "" {
+				t.skip(i, i+n, reason)
+			} else {
+				t.out.WriteString(spec)
+			}
+			i += n
+			continue
+		}
+
+		for _, tok := range momentTokens {
+			if strings.HasPrefix(pattern[i:], tok.token) {
+				if tok.reason != "" {
+					t.skip(i, i+len(tok.token), tok.reason)
+				} else {
+					t.out.WriteString(tok.spec)
+				}
+				i += len(tok.token)
+				continue next
+			}
+		}
+		writeLiteral(&t.out, pattern[i])
+		i++
+	}
+	return t.result()
+}
+
+// luxonTokens maps the format tokens of Luxon's DateTime.toFormat to the equivalent strftime format.
+// Tokens with an empty spec have no strftime equivalent and carry the reason instead.
+var luxonTokens = map[string]struct{ spec, reason string }{
+	// Localized formats, as the "en-US" locale writes them
+	"D":    {spec: "%-m/%-d/%Y"},
+	"DD":   {spec: "%b %-d, %Y"},
+	"DDD":  {spec: "%B %-d, %Y"},
+	"DDDD": {spec: "%A, %B %-d, %Y"},
+	"t":    {spec: "%-I:%M %p"},
+	"tt":   {spec: "%-I:%M:%S %p"},
+	"ttt":  {spec: "%-I:%M:%S %p %Z"},
+	"tttt": {spec: "%-I:%M:%S %p %::Z"},
+	"T":    {spec: "%H:%M"},
+	"TT":   {spec: "%H:%M:%S"},
+	"TTT":  {spec: "%H:%M:%S %Z"},
+	"TTTT": {spec: "%H:%M:%S %::Z"},
+	"f":    {spec: "%-m/%-d/%Y, %-I:%M %p"},
+	"ff":   {spec: "%b %-d, %Y, %-I:%M %p"},
+	"fff":  {spec: "%B %-d, %Y at %-I:%M %p %Z"},
+	"ffff": {spec: "%A, %B %-d, %Y at %-I:%M %p %::Z"},
+	"F":    {spec: "%-m/%-d/%Y, %-I:%M:%S %p"},
+	"FF":   {spec: "%b %-d, %Y, %-I:%M:%S %p"},
+	"FFF":  {spec: "%B %-d, %Y at %-I:%M:%S %p %Z"},
+	"FFFF": {spec: "%A, %B %-d, %Y at %-I:%M:%S %p %::Z"},
+
+	"S":      {reason: "unpadded milliseconds have no strftime equivalent"},
+	"SSS":    {spec: "%L"},
+	"u":      {spec: "%L"},
+	"uu":     {spec: "%2N"},
+	"uuu":    {spec: "%1N"},
+	"s":      {spec: "%-S"},
+	"ss":     {spec: "%S"},
+	"m":      {spec: "%-M"},
+	"mm":     {spec: "%M"},
+	"h":      {spec: "%-I"},
+	"hh":     {spec: "%I"},
+	"H":      {spec: "%-H"},
+	"HH":     {spec: "%H"},
+	"Z":      {reason: "narrow offsets have no strftime equivalent"},
+	"ZZ":     {spec: "%:z"},
+	"ZZZ":    {spec: "%z"},
+	"ZZZZ":   {spec: "%Z"},
+	"ZZZZZ":  {spec: "%::Z"},
+	"z":      {reason: "IANA zone names have no strftime equivalent"},
+	"a":      {spec: "%p"},
+	"d":      {spec: "%-d"},
+	"dd":     {spec: "%d"},
+	"c":      {spec: "%u"},
+	"ccc":    {spec: "%a"},
+	"cccc":   {spec: "%A"},
+	"ccccc":  {reason: "narrow weekday names have no strftime equivalent"},
+	"E":      {spec: "%u"},
+	"EEE":    {spec: "%a"},
+	"EEEE":   {spec: "%A"},
+	"EEEEE":  {reason: "narrow weekday names have no strftime equivalent"},
+	"L":      {spec: "%-m"},
+	"LL":     {spec: "%m"},
+	"LLL":    {spec: "%b"},
+	"LLLL":   {spec: "%B"},
+	"LLLLL":  {reason: "narrow month names have no strftime equivalent"},
+	"M":      {spec: "%-m"},
+	"MM":     {spec: "%m"},
+	"MMM":    {spec: "%b"},
+	"MMMM":   {spec: "%B"},
+	"MMMMM":  {reason: "narrow month names have no strftime equivalent"},
+	"y":      {spec: "%-Y"},
+	"yy":     {spec: "%y"},
+	"yyyy":   {spec: "%Y"},
+	"yyyyyy": {reason: "six-digit years have no strftime equivalent"},
+	"G":      {reason: "era names have no strftime equivalent"},
+	"GG":     {reason: "era names have no strftime equivalent"},
+	"GGGGG":  {reason: "era names have no strftime equivalent"},
+	"kk":     {spec: "%g"},
+	"kkkk":   {spec: "%G"},
+	"W":      {spec: "%-V"},
+	"WW":     {spec: "%V"},
+	"n":      {reason: "local week numbers have no strftime equivalent"},
+	"nn":     {reason: "local week numbers have no strftime equivalent"},
+	"ii":     {reason: "local week years have no strftime equivalent"},
+	"iiii":   {reason: "local week years have no strftime equivalent"},
+	"o":      {spec: "%-j"},
+	"ooo":    {spec: "%j"},
+	"q":      {spec: "%q"},
+	"qq":     {spec: "0%q"},
+	"X":      {spec: "%s"},
+	"x":      {reason: "Unix milliseconds have no strftime equivalent"},
+}
+
+// FromLuxon converts a Luxon DateTime.toFormat format to the equivalent strftime format.
+// Text in single quotes is literal, as are runs of characters that aren't tokens, and the localized
+// formats such as DD and t expand to the patterns of the "en-US" locale.
+//
+// Tokens without a strftime equivalent, such as the zone name z or the local week n, are left out of
+// the result and listed in a *TranslationError. The rest of the format is still translated and returned with it.
+func FromLuxon(pattern string) (string, error) {
+	t := translation{source: pattern}
+	for i := 0; i < len(pattern); {
+		// Single quotes start and end literal text
+		if pattern[i] == '\'' {
+			end := strings.IndexByte(pattern[i+1:], '\'')
+			if end < 0 {
+				end = len(pattern) - i - 1
+			}
+			for k := i + 1; k <= i+end; k++ {
+				writeLiteral(&t.out, pattern[k])
+			}
+			i += end + 2
+			continue
+		}
+
+		// Luxon reads runs of the same character as a token
+		n := 1
+		for i+n < len(pattern) && pattern[i+n] == pattern[i] {
+			n++
+		}
+		run := pattern[i : i+n]
+		if tok, ok := luxonTokens[run]; ok {
+			if tok.reason != "" {
+				t.skip(i, i+n, tok.reason)
+			} else {
+				t.out.WriteString(tok.spec)
+			}
+		} else if c := pattern[i]; 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' {
+			t.skip(i, i+n, "unknown token "+strconv.Quote(run)+", Luxon writes it as is")
+		} else {
+			for k := i; k < i+n; k++ {
+				writeLiteral(&t.out, pattern[k])
+			}
+		}
+		i += n
+	}
+	return t.result()
+}
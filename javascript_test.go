@@ -0,0 +1,95 @@
+package strftime
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFromMoment(t *testing.T) {
+	tm := time.Date(2026, time.October, 2, 21, 5, 7, 123456789, time.FixedZone("IST", 5*3600+1800))
+
+	tests := []struct {
+		pattern  string
+		expected string
+		output   string
+	}{
+		{"YYYY-MM-DD", "%Y-%m-%d", "2026-10-02"},
+		{"Do MMM", "%o %b", "2nd Oct"},
+		{"hh:mm A", "%I:%M %p", "09:05 PM"},
+		{"[Today is] dddd", "Today is %A", "Today is Friday"},
+		{"YYYY-MM-DDTHH:mm:ss.SSSZ", "%Y-%m-%dT%H:%M:%S.%L%:z", "2026-10-02T21:05:07.123+05:30"},
+		{"ddd, D MMMM YY h:m:s a ZZ", "%a, %-d %B %y %-I:%-M:%-S %#p %z", "Fri, 2 October 26 9:5:7 pm +0530"},
+		{"Qo [quarter], DDDo [day], Wo [week], Mo [month]", "%*q quarter, %*j day, %*V week, %*m month", "4th quarter, 275th day, 40th week, 10th month"},
+		{"GGGG-[W]WW-E", "%G-W%V-%u", "2026-W40-5"},
+		{"LL LT", "%B %-d, %Y %-I:%M %p", "October 2, 2026 9:05 PM"},
+		{"l, SSSSSS, X, [[100%]]", "%-m/%-d/%Y, %f, %s, [100%%]", "10/2/2026, 123456, 1790955307, [100%]"},
+	}
+
+	for _, tt := range tests {
+		format, err := FromMoment(tt.pattern)
+		if err != nil {
+			t.Errorf("FromMoment(%q) returned error: %v", tt.pattern, err)
+			continue
+		}
+		if format != tt.expected {
+			t.Errorf("FromMoment(%q): got [%s], expected [%s]", tt.pattern, format, tt.expected)
+		}
+		if got := Strftime(format, tm); got != tt.output {
+			t.Errorf("Strftime(%q): got [%s], expected [%s]", format, got, tt.output)
+		}
+		if f, err := Compile(format); err != nil {
+			t.Errorf("Compile(%q) returned error: %v", format, err)
+		} else if got := f.Format(tm); got != tt.output {
+			t.Errorf("Compile(%q).Format: got [%s], expected [%s]", format, got, tt.output)
+		}
+	}
+
+	format, err := FromMoment("YYYY ww dd k x")
+	if format != "%Y    " {
+		t.Errorf("FromMoment should translate the rest of the pattern, got [%s]", format)
+	}
+	if texts, expected := untranslatedTexts(err), []string{"ww", "dd", "k", "x"}; !reflect.DeepEqual(texts, expected) {
+		t.Errorf("Untranslated tokens: got %q, expected %q", texts, expected)
+	}
+}
+
+func TestFromLuxon(t *testing.T) {
+	tm := time.Date(2026, time.October, 2, 21, 5, 7, 123456789, time.FixedZone("IST", 5*3600+1800))
+
+	tests := []struct {
+		pattern  string
+		expected string
+		output   string
+	}{
+		{"yyyy-MM-dd", "%Y-%m-%d", "2026-10-02"},
+		{"yyyy-LL-dd'T'HH:mm:ss.SSSZZ", "%Y-%m-%dT%H:%M:%S.%L%:z", "2026-10-02T21:05:07.123+05:30"},
+		{"EEEE, d MMMM y 'at' h:mm a", "%A, %-d %B %-Y at %-I:%M %p", "Friday, 2 October 2026 at 9:05 PM"},
+		{"ccc LLL d yy ZZZ ZZZZ", "%a %b %-d %y %z %Z", "Fri Oct 2 26 +0530 IST"},
+		{"kkkk-'W'WW-c ooo q", "%G-W%V-%u %j %q", "2026-W40-5 275 4"},
+		{"DD t", "%b %-d, %Y %-I:%M %p", "Oct 2, 2026 9:05 PM"},
+		{"T, uu, X, 100%", "%H:%M, %2N, %s, 100%%", "21:05, 12, 1790955307, 100%"},
+	}
+
+	for _, tt := range tests {
+		format, err := FromLuxon(tt.pattern)
+		if err != nil {
+			t.Errorf("FromLuxon(%q) returned error: %v", tt.pattern, err)
+			continue
+		}
+		if format != tt.expected {
+			t.Errorf("FromLuxon(%q): got [%s], expected [%s]", tt.pattern, format, tt.expected)
+		}
+		if got := Strftime(format, tm); got != tt.output {
+			t.Errorf("Strftime(%q): got [%s], expected [%s]", format, got, tt.output)
+		}
+	}
+
+	format, err := FromLuxon("yyyy z YYYY n 'z'")
+	if format != "%Y    z" {
+		t.Errorf("FromLuxon should translate the rest of the pattern, got [%s]", format)
+	}
+	if texts, expected := untranslatedTexts(err), []string{"z", "YYYY", "n"}; !reflect.DeepEqual(texts, expected) {
+		t.Errorf("Untranslated tokens: got %q, expected %q", texts, expected)
+	}
+}
@@ -0,0 +1,397 @@
+package strftime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FromLDML converts a Unicode LDML date pattern, as used by ICU, Java and Android, to the equivalent strftime format.
+// The week fields w and Y are taken as ISO 8601 weeks (%V and %G), as in locales whose weeks start on Monday,
+// and ISO 8601 offsets with optional seconds (XXXX, XXXXX, ZZZZZ) as offsets without seconds.
+//
+// Fields without a strftime equivalent, such as the era G or the hours k and K, and literal text whose quote
+// is never closed are left out of the result and listed in a *TranslationError. The rest of the pattern is still translated and returned with it.
+func FromLDML(pattern string) (string, error) {
+	t := translation{source: pattern}
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		switch {
+		case c == '\'':
+			// '' is a quote, otherwise the text up to the closing quote is literal, with '' standing for a quote
+			if i+1 < len(pattern) && pattern[i+1] == '\'' {
+				t.out.WriteByte('\'')
+				i += 2
+				continue
+			}
+			end := quoteEnd(pattern, i+1)
+			if end < 0 {
+				t.skip(i, len(pattern), "unterminated quote")
+				i = len(pattern)
+				continue
+			}
+			for i++; i < end-1; i++ {
+				if pattern[i] == '\'' {
+					i++
+				}
+				writeLiteral(&t.out, pattern[i])
+			}
+			i = end
+		case 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+			n := 1
+			for i+n < len(pattern) && pattern[i+n] == c {
+				n++
+			}
+			if spec, reason := ldmlField(c, n); reason != "" {
+				t.skip(i, i+n, reason)
+			} else {
+				t.out.WriteString(spec)
+			}
+			i += n
+		default:
+			writeLiteral(&t.out, c)
+			i++
+		}
+	}
+	return t.result()
+}
+
+// quoteEnd returns the index just past the quote that closes the literal text starting at pattern[i],
+// skipping doubled quotes, or -1 if the pattern ends first
+func quoteEnd(pattern string, i int) int {
+	for ; i < len(pattern); i++ {
+		if pattern[i] != '\'' {
+			continue
+		}
+		if i+1 >= len(pattern) || pattern[i+1] != '\'' {
+			return i + 1
+		}
+		i++
+	}
+	return -1
+}
+
+// writeLiteral writes c to a strftime format, escaping '%'
+func writeLiteral(b *strings.Builder, c byte) {
+	if c == '%' {
+		b.WriteString("%%")
+		return
+	}
+	b.WriteByte(c)
+}
+
+// ldmlField returns the strftime equivalent of the LDML field of n repetitions of the letter c,
+// or the reason there is none
+func ldmlField(c byte, n int) (spec, reason string) {
+	// number maps one and two letters to the unpadded and padded forms of spec
+	number := func(spec string) (string, string) {
+		switch n {
+		case 1:
+			return "%-" + spec, ""
+		case 2:
+			return "%" + spec, ""
+		}
+		return "", "more than two digits have no strftime equivalent"
+	}
+
+	// year maps a year field to full, with two letters selecting the two-digit year short
+	year := func(full, short string) (string, string) {
+		switch n {
+		case 1:
+			return "%-" + full, ""
+		case 2:
+			return "%" + short, ""
+		case 4:
+			return "%" + full, ""
+		}
+		return "%0" + strconv.Itoa(n) + full, ""
+	}
+
+	switch c {
+	case 'y', 'u': // Year, extended year
+		return year("Y", "y")
+	case 'Y': // Year of the week-based year
+		return year("G", "g")
+	case 'Q', 'q': // Quarter
+		switch n {
+		case 1:
+			return "%q", ""
+		case 2:
+			return "0%q", ""
+		case 3:
+			return "Q%q", ""
+		}
+		return "", "quarter names have no strftime equivalent"
+	case 'M', 'L': // Month
+		switch n {
+		case 3:
+			return "%b", ""
+		case 4:
+			return "%B", ""
+		case 5:
+			return "", "narrow month names have no strftime equivalent"
+		}
+		return number("m")
+	case 'w': // Week of the week-based year
+		return number("V")
+	case 'W': // Week of month
+		if n == 1 {
+			return "%K", ""
+		}
+	case 'd': // Day of month
+		return number("d")
+	case 'D': // Day of year
+		switch n {
+		case 1:
+			return "%-j", ""
+		case 3:
+			return "%j", ""
+		}
+		return "", "two-digit days of year have no strftime equivalent"
+	case 'E', 'e', 'c': // Day of week
+		switch {
+		case c != 'E' && n <= 2:
+			return "", "local day of week numbers have no strftime equivalent"
+		case n <= 3:
+			return "%a", ""
+		case n == 4:
+			return "%A", ""
+		}
+		return "", "narrow and short weekday names have no strftime equivalent"
+	case 'a': // AM or PM
+		if n <= 3 {
+			return "%p", ""
+		}
+		return "", "wide and narrow day periods have no strftime equivalent"
+	case 'h': // Hour 1-12
+		return number("I")
+	case 'H': // Hour 0-23
+		return number("H")
+	case 'k':
+		return "", "hours 1-24 have no strftime equivalent"
+	case 'K':
+		return "", "hours 0-11 have no strftime equivalent"
+	case 'm': // Minute
+		return number("M")
+	case 's': // Second
+		return number("S")
+	case 'S': // Fractional seconds
+		switch n {
+		case 3:
+			return "%L", ""
+		case 6:
+			return "%f", ""
+		case 9:
+			return "%N", ""
+		}
+		if n > 9 {
+			return "", "more than nine fractional digits have no strftime equivalent"
+		}
+		return "%" + strconv.Itoa(n) + "N", ""
+	case 'z': // Specific non-location zone name
+		if n <= 3 {
+			return "%Z", ""
+		}
+		return "%::Z", ""
+	case 'v': // Generic non-location zone name
+		if n == 4 {
+			return "%:::Z", ""
+		}
+		return "", "short generic zone names have no strftime equivalent"
+	case 'Z': // ISO 8601 basic offset, localized GMT format or ISO 8601 extended offset
+		switch {
+		case n <= 3:
+			return "%z", ""
+		case n == 5:
+			return "%:Ez", ""
+		}
+		return "", "the localized GMT format has no strftime equivalent"
+	case 'X', 'x': // ISO 8601 offset, with "Z" for UTC in the X forms
+		specs := [...]string{"", "%z", "%:z", "%z", "%:z"}
+		if n == 1 || n > len(specs) {
+			return "", "offsets with optional minutes have no strftime equivalent"
+		}
+		if c == 'X' {
+			return specs[n-1][:len(specs[n-1])-1] + "Ez", ""
+		}
+		return specs[n-1], ""
+	}
+	return "", fmt.Sprintf("the field %q has no strftime equivalent", strings.Repeat(string(c), n))
+}
+
+// ToLDML converts a strftime format to the equivalent Unicode LDML date pattern, as used by ICU, Java
+// and Android, quoting literal text. Composite specifiers such as %F and %T are expanded with the names
+// of DefaultLocale, and %V and %G become the week fields w and Y, which follow ISO 8601 in locales whose
+// weeks start on Monday.
+//
+// Specifiers without an LDML equivalent, such as %U, %e or flags like '^', are left out of the result
+// and listed in a *TranslationError. The rest of the format is still translated and returned with it.
+func ToLDML(format string) (string, error) {
+	w := ldmlWriter{t: translation{source: format}}
+	opts := options{locale: DefaultLocale, unknown: UnknownSpecifierError}
+	w.format(format, &opts, w.t.skip)
+	w.flush()
+	return w.t.result()
+}
+
+// ldmlWriter builds an LDML pattern, collecting literal text so that it can be quoted as a whole
+type ldmlWriter struct {
+	t       translation
+	literal []byte
+}
+
+// format appends the LDML pattern for format, calling skip with the bounds of elements without an equivalent
+func (w *ldmlWriter) format(format string, opts *options, skip func(start, end int, reason string)) {
+	i := 0
+	for i < len(format) {
+		if format[i] != '%' {
+			w.literal = append(w.literal, format[i])
+			i++
+			continue
+		}
+
+		o, next := scanDirective(format, i, opts)
+		switch {
+		case o.kind == opUnknown:
+			skip(i, next, "unknown specifier")
+		case o.kind == opLiteral && o.plain():
+			w.literal = append(w.literal, o.text...)
+		case o.kind == opComposite && o.plain() && opts.depth < maxCompositeDepth:
+			nested := *opts
+			nested.depth++
+			w.format(o.text, &nested, func(start, end int, reason string) {
+				skip(i, next, fmt.Sprintf("%s in its expansion %q: %s", o.text[start:end], o.text, reason))
+			})
+		default:
+			pattern, reason := o.ldml()
+			if reason != "" {
+				skip(i, next, reason)
+				break
+			}
+			w.flush()
+			w.t.out.WriteString(pattern)
+		}
+		i = next
+	}
+}
+
+// flush writes the pending literal text, quoting the span from its first to its last letter,
+// which LDML would otherwise read as fields
+func (w *ldmlWriter) flush() {
+	if len(w.literal) == 0 {
+		return
+	}
+	isLetter := func(r rune) bool { return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' }
+	text := string(w.literal)
+	if first := strings.IndexFunc(text, isLetter); first >= 0 {
+		last := strings.LastIndexFunc(text, isLetter) + 1
+		w.t.out.WriteString(strings.ReplaceAll(text[:first], "'", "''"))
+		w.t.out.WriteString("'" + strings.ReplaceAll(text[first:last], "'", "''") + "'")
+		text = text[last:]
+	}
+	w.t.out.WriteString(strings.ReplaceAll(text, "'", "''"))
+	w.literal = w.literal[:0]
+}
+
+// ldml returns the LDML field that writes the same text as the op, or the reason there is none
+func (o op) ldml() (pattern, reason string) {
+	switch {
+	case o.width > 0:
+		return "", "field widths have no LDML equivalent"
+	case o.textCase != caseNone:
+		return "", "case conversion has no LDML equivalent"
+	case o.alt:
+		return "", "alternative digits have no LDML equivalent"
+	case o.ordinal:
+		return "", "ordinal suffixes have no LDML equivalent"
+	case o.sign:
+		return "", "signed years have no LDML equivalent"
+	}
+
+	switch o.kind {
+	case opName:
+		return [...]string{
+			nameWeekdayFull:   "EEEE",
+			nameWeekdayAbbrev: "EEE",
+			nameMonthFull:     "MMMM",
+			nameMonthAbbrev:   "MMM",
+			nameAMPM:          "a",
+		}[o.names], ""
+	case opFraction:
+		if o.digits > 9 {
+			return "", "more than nine fractional digits have no LDML equivalent"
+		}
+		return strings.Repeat("S", o.digits), ""
+	case opOffset:
+		switch {
+		case o.digits > 1:
+			return "", "offsets with seconds have no LDML equivalent"
+		case o.zulu:
+			return [...]string{"XX", "XXX"}[o.digits], ""
+		}
+		return [...]string{"xx", "xxx"}[o.digits], ""
+	case opLayout: // %Z
+		return "z", ""
+	case opZoneName:
+		return [...]string{zoneShort: "z", zoneLong: "zzzz", zoneGeneric: "vvvv"}[o.digits], ""
+	case opNumber:
+		return o.ldmlNumber()
+	}
+	return "", "no LDML equivalent"
+}
+
+// ldmlNumber returns the LDML field for an opNumber, or the reason there is none
+func (o op) ldmlNumber() (pattern, reason string) {
+	count := max(o.digits, 1)
+	if count > 1 && o.pad != '0' {
+		return "", "space padding has no LDML equivalent"
+	}
+
+	var letter string
+	maxCount := 2
+	switch o.field {
+	case fieldYear, fieldISOYear:
+		letter, maxCount = "y", 9
+		if o.field == fieldISOYear {
+			letter = "Y"
+		}
+		// Two letters select the two-digit year
+		if count == 2 {
+			return "", "years padded to two digits have no LDML equivalent"
+		}
+	case fieldYear2, fieldISOYear2:
+		if count != 2 {
+			return "", "unpadded two-digit years have no LDML equivalent"
+		}
+		if o.field == fieldISOYear2 {
+			return "YY", ""
+		}
+		return "yy", ""
+	case fieldMonth:
+		letter = "M"
+	case fieldDay:
+		letter = "d"
+	case fieldYearDay:
+		letter, maxCount = "D", 3
+	case fieldHour:
+		letter = "H"
+	case fieldHour12:
+		letter = "h"
+	case fieldMinute:
+		letter = "m"
+	case fieldSecond:
+		letter = "s"
+	case fieldISOWeek:
+		letter = "w"
+	case fieldQuarter:
+		letter = "Q"
+	case fieldWeekOfMonth:
+		letter, maxCount = "W", 1
+	default:
+		return "", "no LDML equivalent"
+	}
+	if count > maxCount {
+		return "", fmt.Sprintf("more than %d digits have no LDML equivalent", maxCount)
+	}
+	return strings.Repeat(letter, count), ""
+}
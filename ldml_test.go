@@ -0,0 +1,174 @@
+package strftime
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFromLDML(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{"yyyy-MM-dd'T'HH:mm:ss.SSSXXX", "%Y-%m-%dT%H:%M:%S.%L%:Ez"},
+		{"uuuu-MM-dd HH:mm:ss.SSSSSS xx", "%Y-%m-%d %H:%M:%S.%f %z"},
+		{"EEEE, MMMM d, y 'at' h:mm a", "%A, %B %-d, %-Y at %-I:%M %p"},
+		{"EEE, dd MMM yy HH:mm:ss Z", "%a, %d %b %y %H:%M:%S %z"},
+		{"yyyy 'Q'Q, QQ, QQQ", "%Y Q%q, 0%q, Q%q"},
+		{"LLL LLLL D DDD W", "%b %B %-j %j %K"},
+		{"ccc cccc H:m:s.SSSSSSSSS", "%a %A %-H:%-M:%-S.%N"},
+		{"z zzzz vvvv ZZZZZ XX xxx", "%Z %::Z %:::Z %:Ez %Ez %:z"},
+		{"h 'o''clock' ''", "%-I o'clock '"},
+		{"dd.MM.yyyy, 100%", "%d.%m.%Y, 100%%"},
+		{"yyyyy S", "%05Y %1N"},
+	}
+
+	for _, tt := range tests {
+		format, err := FromLDML(tt.pattern)
+		if err != nil {
+			t.Errorf("FromLDML(%q) returned error: %v", tt.pattern, err)
+			continue
+		}
+		if format != tt.expected {
+			t.Errorf("FromLDML(%q): got [%s], expected [%s]", tt.pattern, format, tt.expected)
+		}
+	}
+}
+
+func TestFromLDML_Untranslated(t *testing.T) {
+	format, err := FromLDML("G yyyy-MM-dd kk:mm K 'k' EEEEE")
+	if format != " %Y-%m-%d :%M  k " {
+		t.Errorf("FromLDML should translate the rest of the pattern, got [%s]", format)
+	}
+
+	var terr *TranslationError
+	if !errors.As(err, &terr) {
+		t.Fatalf("FromLDML expected a *TranslationError, got %v", err)
+	}
+	var texts []string
+	var offsets []int
+	for _, u := range terr.Untranslated {
+		texts = append(texts, u.Text)
+		offsets = append(offsets, u.Offset)
+		if u.Reason == "" {
+			t.Errorf("Untranslated %q has no reason", u.Text)
+		}
+	}
+	if expected := []string{"G", "kk", "K", "EEEEE"}; !reflect.DeepEqual(texts, expected) {
+		t.Errorf("Untranslated fields: got %q, expected %q", texts, expected)
+	}
+	if expected := []int{0, 13, 19, 25}; !reflect.DeepEqual(offsets, expected) {
+		t.Errorf("Untranslated offsets: got %v, expected %v", offsets, expected)
+	}
+
+	for _, pattern := range []string{"e", "v", "X", "ZZZZ", "MMMMM", "QQQQ", "DD", "SSSSSSSSSS", "ww'W'WW", "A", "'unterminated", "HH 'h''"} {
+		if format, err := FromLDML(pattern); err == nil {
+			t.Errorf("FromLDML(%q) expected error, but got [%s]", pattern, format)
+		}
+	}
+}
+
+func TestFromLDML_UnterminatedQuote(t *testing.T) {
+	format, err := FromLDML("HH:mm 'Uhr")
+	if format != "%H:%M " {
+		t.Errorf("FromLDML should translate the pattern up to the quote, got [%s]", format)
+	}
+	var terr *TranslationError
+	if !errors.As(err, &terr) || len(terr.Untranslated) != 1 {
+		t.Fatalf("FromLDML expected a *TranslationError with one element, got %v", err)
+	}
+	if u := terr.Untranslated[0]; u.Offset != 6 || u.Text != "'Uhr" {
+		t.Errorf("Untranslated: got [%d %s], expected [6 'Uhr]", u.Offset, u.Text)
+	}
+}
+
+func TestFromLDML_RoundTrip(t *testing.T) {
+	patterns := []string{
+		"yyyy-MM-dd'T'HH:mm:ss.SSSXXX",
+		"EEEE, MMMM d, y h:mm:ss a",
+		"YYYY-'W'ww EEE HH:mm",
+		"yyyy QQQ D HH:mm",
+	}
+
+	tm := time.Date(2024, time.December, 30, 15, 4, 5, 123000000, time.UTC)
+	for _, pattern := range patterns {
+		format, err := FromLDML(pattern)
+		if err != nil {
+			t.Errorf("FromLDML(%q) returned error: %v", pattern, err)
+			continue
+		}
+		parsed, err := Parse(format, Strftime(format, tm))
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", format, err)
+			continue
+		}
+		expected := tm.Truncate(time.Minute)
+		if !parsed.Truncate(time.Minute).Equal(expected) {
+			t.Errorf("Round trip of %q: got [%v], expected [%v]", format, parsed, expected)
+		}
+	}
+}
+
+func TestToLDML(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%Y-%m-%dT%H:%M:%S.%L%:Ez", "yyyy-MM-dd'T'HH:mm:ss.SSSXXX"},
+		{"%F %T %z", "yyyy-MM-dd HH:mm:ss xx"},
+		{"%A, %B %-d, %Y at %-I:%M %p", "EEEE, MMMM d, yyyy 'at' h:mm a"},
+		{"%a %b %d %y %Ez %:z", "EEE MMM dd yy XX xxx"},
+		{"%G-W%V-%j Q%q %K", "YYYY-'W'ww-DDD 'Q'Q W"},
+		{"%Z %:Z %::Z %:::Z", "z z zzzz vvvv"},
+		{"%H:%M:%S.%f %3N %N", "HH:mm:ss.SSSSSS SSS SSSSSSSSS"},
+		{"%Hh it's %% done", "HH'h it''s % done'"},
+		{"%d/%m '%y'", "dd/MM ''yy''"},
+	}
+
+	for _, tt := range tests {
+		pattern, err := ToLDML(tt.format)
+		if err != nil {
+			t.Errorf("ToLDML(%q) returned error: %v", tt.format, err)
+			continue
+		}
+		if pattern != tt.expected {
+			t.Errorf("ToLDML(%q): got [%s], expected [%s]", tt.format, pattern, tt.expected)
+		}
+		if back, err := FromLDML(pattern); err != nil {
+			t.Errorf("FromLDML(%q) returned error: %v", pattern, err)
+		} else if tm := time.Date(2025, time.March, 4, 9, 5, 7, 0, time.UTC); Strftime(back, tm) != Strftime(tt.format, tm) {
+			t.Errorf("Round trip of %q through [%s]: got [%s], expected [%s]", tt.format, pattern, Strftime(back, tm), Strftime(tt.format, tm))
+		}
+	}
+}
+
+func TestToLDML_Untranslated(t *testing.T) {
+	pattern, err := ToLDML("%Y-%U %e %^a %Q %v")
+	if pattern != "yyyy-    -MMM-yyyy" {
+		t.Errorf("ToLDML should translate the rest of the format, got [%s]", pattern)
+	}
+
+	var terr *TranslationError
+	if !errors.As(err, &terr) {
+		t.Fatalf("ToLDML expected a *TranslationError, got %v", err)
+	}
+	var texts []string
+	for _, u := range terr.Untranslated {
+		texts = append(texts, u.Text)
+	}
+	// %v expands to "%e-%b-%Y", whose %e can't be translated
+	if expected := []string{"%U", "%e", "%^a", "%Q", "%v"}; !reflect.DeepEqual(texts, expected) {
+		t.Errorf("Untranslated specifiers: got %q, expected %q", texts, expected)
+	}
+	if expected := `strftime: cannot translate "%Y-%U %e %^a %Q %v": "%U" at offset 3 (no LDML equivalent);`; len(err.Error()) < len(expected) || err.Error()[:len(expected)] != expected {
+		t.Errorf("Error message: got %q", err.Error())
+	}
+
+	for _, format := range []string{"%s", "%u", "%k", "%10A", "%*d", "%::z", "%Oy", "%-y", "%2Y", "%12N"} {
+		if pattern, err := ToLDML(format); err == nil {
+			t.Errorf("ToLDML(%q) expected error, but got [%s]", format, pattern)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package lestrrat
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Equationzhao/strftime"
+)
+
+// Appender appends the text of a specification for a time to a buffer
+type Appender interface {
+	Append([]byte, time.Time) []byte
+}
+
+// AppendFunc is a function that implements Appender
+type AppendFunc func([]byte, time.Time) []byte
+
+// Append calls f(b, t)
+func (f AppendFunc) Append(b []byte, t time.Time) []byte {
+	return f(b, t)
+}
+
+// specification is an Appender backed by a strftime format.
+// New merges adjacent specifications into a single precompiled strftime.Formatter.
+type specification string
+
+// Append formats t with the format of the specification and the default locale
+func (s specification) Append(b []byte, t time.Time) []byte {
+	return strftime.AppendStrftime(b, string(s), t, strftime.DefaultLocale)
+}
+
+// StrftimeFormat returns an Appender that writes t formatted with a format of this module,
+// such as "%Ey" or "%-d", using the names of the locale given to New
+func StrftimeFormat(format string) Appender {
+	return specification(format)
+}
+
+// Verbatim returns an Appender that writes s as is
+func Verbatim(s string) Appender {
+	return specification(strings.ReplaceAll(s, "%", "%%"))
+}
+
+// stdlibFormat is an Appender backed by a Go layout
+type stdlibFormat string
+
+// Append formats t with the Go layout
+func (s stdlibFormat) Append(b []byte, t time.Time) []byte {
+	return t.AppendFormat(b, string(s))
+}
+
+// StdlibFormat returns an Appender that writes t formatted with the Go layout s, as time.Time.Format does
+func StdlibFormat(s string) Appender {
+	return stdlibFormat(s)
+}
+
+// Appenders for the optional specifications enabled with WithMilliseconds, WithMicroseconds and WithUnixSeconds
+var (
+	Milliseconds = StrftimeFormat("%L") // Milliseconds, 3 digits
+	Microseconds = StrftimeFormat("%f") // Microseconds, 6 digits
+	UnixSeconds  = StrftimeFormat("%s") // Seconds since the Unix epoch
+)
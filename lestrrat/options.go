@@ -0,0 +1,57 @@
+package lestrrat
+
+import "github.com/Equationzhao/strftime"
+
+// Option configures New and Format
+type Option func(*config)
+
+// config holds the settings collected from Option values
+type config struct {
+	specifications SpecificationSet
+	extra          []extraSpecification
+	locale         *strftime.Locale
+}
+
+// extraSpecification is a binding added with WithSpecification
+type extraSpecification struct {
+	c        byte
+	appender Appender
+}
+
+// WithSpecificationSet uses ds instead of the default specifications
+func WithSpecificationSet(ds SpecificationSet) Option {
+	return func(c *config) {
+		c.specifications = ds
+	}
+}
+
+// WithSpecification binds the character c to a.
+// It changes the set given with WithSpecificationSet, or a copy of the default specifications.
+func WithSpecification(c byte, a Appender) Option {
+	return func(cfg *config) {
+		cfg.extra = append(cfg.extra, extraSpecification{c, a})
+	}
+}
+
+// WithMilliseconds binds the character c to the milliseconds, 3 digits
+func WithMilliseconds(c byte) Option {
+	return WithSpecification(c, Milliseconds)
+}
+
+// WithMicroseconds binds the character c to the microseconds, 6 digits
+func WithMicroseconds(c byte) Option {
+	return WithSpecification(c, Microseconds)
+}
+
+// WithUnixSeconds binds the character c to the seconds since the Unix epoch
+func WithUnixSeconds(c byte) Option {
+	return WithSpecification(c, UnixSeconds)
+}
+
+// WithLocale sets the locale for the names of the default specifications and of StrftimeFormat, nil selects
+// strftime.DefaultLocale. It has no counterpart in lestrrat-go/strftime, which only writes English names.
+func WithLocale(loc *strftime.Locale) Option {
+	return func(c *config) {
+		c.locale = loc
+	}
+}
@@ -0,0 +1,116 @@
+package lestrrat
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// SpecificationSet maps the characters following '%' to the Appenders that write them
+type SpecificationSet interface {
+	Lookup(byte) (Appender, error)
+	Delete(byte) error
+	Set(byte, Appender) error
+}
+
+// defaultSpecifications holds the formats of the specifications of lestrrat-go/strftime.
+// The composites are spelled out, since they differ from the defaults of strftime.Locale.
+var defaultSpecifications = map[byte]string{
+	'A': "%A",                   // Full weekday name
+	'a': "%a",                   // Abbreviated weekday name
+	'B': "%B",                   // Full month name
+	'b': "%b",                   // Abbreviated month name
+	'C': "%C",                   // Century, 2 digits
+	'c': "%a %b %e %H:%M:%S %Y", // Date and time, as the Go layout "Mon Jan _2 15:04:05 2006"
+	'D': "%m/%d/%y",             // Date, as %m/%d/%y
+	'd': "%d",                   // Day of month, 2 digits
+	'e': "%e",                   // Day of month, padded with a space
+	'F': "%Y-%m-%d",             // Date, as %Y-%m-%d
+	'H': "%H",                   // Hour in 24h format, 2 digits
+	'h': "%b",                   // Same as %b
+	'I': "%I",                   // Hour in 12h format, 2 digits
+	'j': "%j",                   // Day of year, 3 digits
+	'k': "%k",                   // Hour in 24h format, padded with a space
+	'l': "%l",                   // Hour in 12h format, padded with a space
+	'M': "%M",                   // Minute, 2 digits
+	'm': "%m",                   // Month, 2 digits
+	'n': "\n",                   // Newline
+	'p': "%p",                   // AM or PM
+	'R': "%H:%M",                // Time, as %H:%M
+	'r': "%I:%M:%S %p",          // Time in 12h format, as %I:%M:%S %p
+	'S': "%S",                   // Second, 2 digits
+	'T': "%H:%M:%S",             // Time, as %H:%M:%S
+	't': "\t",                   // Tab
+	'U': "%U",                   // Week of year, weeks starting on Sunday
+	'u': "%u",                   // Weekday (1-7, Monday is 1)
+	'V': "%V",                   // ISO 8601 week number
+	'v': "%e-%b-%Y",             // Date, as %e-%b-%Y
+	'W': "%W",                   // Week of year, weeks starting on Monday
+	'w': "%w",                   // Weekday (0-6, Sunday is 0)
+	'X': "%H:%M:%S",             // Time, as %H:%M:%S
+	'x': "%m/%d/%y",             // Date, as %m/%d/%y
+	'Y': "%Y",                   // Year, 4 digits
+	'y': "%y",                   // Year, 2 digits
+	'Z': "%Z",                   // Time zone abbreviation
+	'z': "%z",                   // Offset, -0700
+	'%': "%%",                   // Percent sign
+}
+
+// defaultSpecificationSet is used by New unless WithSpecificationSet or WithSpecification is given
+var defaultSpecificationSet = newSpecificationSet(false)
+
+// specificationSet is a SpecificationSet that is safe for concurrent use by multiple goroutines
+type specificationSet struct {
+	mutable bool
+	mu      sync.RWMutex
+	store   map[byte]Appender
+}
+
+// NewSpecificationSet returns a SpecificationSet holding the default specifications, which may be changed
+func NewSpecificationSet() SpecificationSet {
+	return newSpecificationSet(true)
+}
+
+func newSpecificationSet(mutable bool) *specificationSet {
+	ds := &specificationSet{mutable: mutable, store: make(map[byte]Appender, len(defaultSpecifications))}
+	for c, format := range defaultSpecifications {
+		ds.store[c] = specification(format)
+	}
+	return ds
+}
+
+// Lookup returns the Appender bound to c
+func (ds *specificationSet) Lookup(c byte) (Appender, error) {
+	ds.mu.RLock()
+	defer ds.mu.RUnlock()
+	a, ok := ds.store[c]
+	if !ok {
+		return nil, fmt.Errorf("lookup failed: '%%%c' was not found in specification set", c)
+	}
+	return a, nil
+}
+
+// Delete removes the binding of c
+func (ds *specificationSet) Delete(c byte) error {
+	if !ds.mutable {
+		return errors.New("delete failed: this specification set is marked immutable")
+	}
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	delete(ds.store, c)
+	return nil
+}
+
+// Set binds c to a, replacing any previous binding of c
+func (ds *specificationSet) Set(c byte, a Appender) error {
+	if !ds.mutable {
+		return errors.New("set failed: this specification set is marked immutable")
+	}
+	if a == nil {
+		return fmt.Errorf("set failed: nil Appender for '%%%c'", c)
+	}
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	ds.store[c] = a
+	return nil
+}
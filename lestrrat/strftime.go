@@ -0,0 +1,133 @@
+// Package lestrrat provides the API of github.com/lestrrat-go/strftime on top of this module.
+// Importing it under the name strftime lets existing call sites compile unchanged:
+//
+//	import strftime "github.com/Equationzhao/strftime/lestrrat"
+//
+// The default specifications write the same text as lestrrat-go/strftime. Runs of them are compiled
+// into a single strftime.Formatter, and WithLocale selects the names they use.
+package lestrrat
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Equationzhao/strftime"
+)
+
+// Strftime is a compiled pattern.
+// It is safe for concurrent use by multiple goroutines as long as its Appenders are.
+type Strftime struct {
+	pattern   string
+	appenders []Appender
+}
+
+// formatter is an Appender for a run of specifications compiled together
+type formatter struct {
+	f *strftime.Formatter
+}
+
+func (f formatter) Append(b []byte, t time.Time) []byte {
+	return f.f.AppendFormat(b, t)
+}
+
+// New compiles pattern. Every '%' must be followed by a character of the specification set,
+// and an unknown specification or a trailing '%' is an error.
+func New(pattern string, options ...Option) (*Strftime, error) {
+	var cfg config
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	ds := cfg.specifications
+	if ds == nil {
+		ds = defaultSpecificationSet
+	}
+	if len(cfg.extra) > 0 {
+		if ds == SpecificationSet(defaultSpecificationSet) {
+			ds = NewSpecificationSet()
+		}
+		for _, e := range cfg.extra {
+			if err := ds.Set(e.c, e.appender); err != nil {
+				return nil, fmt.Errorf("failed to set specification '%%%c': %w", e.c, err)
+			}
+		}
+	}
+
+	appenders, err := compile(pattern, ds, cfg.locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile pattern: %w", err)
+	}
+	return &Strftime{pattern: pattern, appenders: appenders}, nil
+}
+
+// compile returns the Appenders for pattern, merging adjacent text and specifications into one strftime.Formatter
+func compile(pattern string, ds SpecificationSet, loc *strftime.Locale) ([]Appender, error) {
+	var appenders []Appender
+	var run strings.Builder
+	flush := func() {
+		if run.Len() == 0 {
+			return
+		}
+		appenders = append(appenders, formatter{strftime.MustCompile(run.String(), strftime.WithLocale(loc))})
+		run.Reset()
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		if c != '%' {
+			run.WriteByte(c)
+			continue
+		}
+		if i++; i == len(pattern) {
+			return nil, errors.New("stray % at the end of pattern")
+		}
+		a, err := ds.Lookup(pattern[i])
+		if err != nil {
+			return nil, err
+		}
+		if s, ok := a.(specification); ok {
+			run.WriteString(string(s))
+			continue
+		}
+		flush()
+		appenders = append(appenders, a)
+	}
+	flush()
+	return appenders, nil
+}
+
+// Format formats t according to pattern, compiling it with New each time
+func Format(pattern string, t time.Time, options ...Option) (string, error) {
+	f, err := New(pattern, options...)
+	if err != nil {
+		return "", err
+	}
+	return f.FormatString(t), nil
+}
+
+// Pattern returns the pattern f was compiled from
+func (f *Strftime) Pattern() string {
+	return f.pattern
+}
+
+// Format formats t according to the compiled pattern and writes the result to w
+func (f *Strftime) Format(w io.Writer, t time.Time) error {
+	_, err := w.Write(f.FormatBuffer(make([]byte, 0, len(f.pattern)+10), t))
+	return err
+}
+
+// FormatBuffer formats t according to the compiled pattern and appends the result to dst
+func (f *Strftime) FormatBuffer(dst []byte, t time.Time) []byte {
+	for _, a := range f.appenders {
+		dst = a.Append(dst, t)
+	}
+	return dst
+}
+
+// FormatString formats t according to the compiled pattern
+func (f *Strftime) FormatString(t time.Time) string {
+	return string(f.FormatBuffer(make([]byte, 0, len(f.pattern)+10), t))
+}
@@ -0,0 +1,200 @@
+package lestrrat
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Equationzhao/strftime"
+)
+
+func TestFormat_Specifications(t *testing.T) {
+	// Expected values follow the specification table of lestrrat-go/strftime
+	monday := time.Date(2006, time.January, 2, 15, 4, 5, 123456789, time.FixedZone("MST", -7*3600))
+	sunday := time.Date(2023, time.December, 31, 0, 7, 9, 0, time.UTC)
+
+	tests := []struct {
+		pattern  string
+		t        time.Time
+		expected string
+	}{
+		{"%A", monday, "Monday"},
+		{"%a", monday, "Mon"},
+		{"%B", monday, "January"},
+		{"%b", monday, "Jan"},
+		{"%C", monday, "20"},
+		{"%c", monday, "Mon Jan  2 15:04:05 2006"},
+		{"%c", sunday, "Sun Dec 31 00:07:09 2023"},
+		{"%D", monday, "01/02/06"},
+		{"%d", monday, "02"},
+		{"%e", monday, " 2"},
+		{"%F", monday, "2006-01-02"},
+		{"%H", monday, "15"},
+		{"%H", sunday, "00"},
+		{"%h", monday, "Jan"},
+		{"%I", monday, "03"},
+		{"%I", sunday, "12"},
+		{"%j", monday, "002"},
+		{"%j", sunday, "365"},
+		{"%k", monday, "15"},
+		{"%k", sunday, " 0"},
+		{"%l", monday, " 3"},
+		{"%l", sunday, "12"},
+		{"%M", monday, "04"},
+		{"%m", monday, "01"},
+		{"%n", monday, "\n"},
+		{"%p", monday, "PM"},
+		{"%p", sunday, "AM"},
+		{"%R", monday, "15:04"},
+		{"%r", monday, "03:04:05 PM"},
+		{"%S", monday, "05"},
+		{"%T", monday, "15:04:05"},
+		{"%t", monday, "\t"},
+		{"%U", monday, "01"},
+		{"%U", sunday, "53"},
+		{"%u", monday, "1"},
+		{"%u", sunday, "7"},
+		{"%V", monday, "01"},
+		{"%V", sunday, "52"},
+		{"%v", monday, " 2-Jan-2006"},
+		{"%W", monday, "01"},
+		{"%W", sunday, "52"},
+		{"%w", monday, "1"},
+		{"%w", sunday, "0"},
+		{"%X", monday, "15:04:05"},
+		{"%x", monday, "01/02/06"},
+		{"%Y", monday, "2006"},
+		{"%y", monday, "06"},
+		{"%Z", monday, "MST"},
+		{"%z", monday, "-0700"},
+		{"%z", sunday, "+0000"},
+		{"%%", monday, "%"},
+		{"%Y/%m/%d %H:%M:%S %%s", monday, "2006/01/02 15:04:05 %s"},
+	}
+
+	for _, tt := range tests {
+		got, err := Format(tt.pattern, tt.t)
+		if err != nil {
+			t.Errorf("Format(%q) returned error: %v", tt.pattern, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("Format(%q): got [%s], expected [%s]", tt.pattern, got, tt.expected)
+		}
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	for _, pattern := range []string{"%Y-%Q", "%Y %", "%L", "%s"} {
+		if _, err := New(pattern); err == nil {
+			t.Errorf("New(%q) should return an error", pattern)
+		}
+	}
+
+	if err := defaultSpecificationSet.Set('L', Milliseconds); err == nil {
+		t.Error("The default specification set should be immutable")
+	}
+	if err := defaultSpecificationSet.Delete('Y'); err == nil {
+		t.Error("The default specification set should be immutable")
+	}
+	if _, err := defaultSpecificationSet.Lookup('L'); err == nil {
+		t.Error("Lookup of an unset specification should return an error")
+	}
+}
+
+func TestStrftime_Methods(t *testing.T) {
+	tm := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+	f, err := New("%Y-%m-%d %H:%M")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "2006-01-02 15:04"
+	if got := f.FormatString(tm); got != expected {
+		t.Errorf("FormatString: got [%s], expected [%s]", got, expected)
+	}
+	if got := string(f.FormatBuffer([]byte("at "), tm)); got != "at "+expected {
+		t.Errorf("FormatBuffer: got [%s], expected [%s]", got, "at "+expected)
+	}
+	var buf bytes.Buffer
+	if err := f.Format(&buf, tm); err != nil || buf.String() != expected {
+		t.Errorf("Format: got [%s] (%v), expected [%s]", buf.String(), err, expected)
+	}
+	if got := f.Pattern(); got != "%Y-%m-%d %H:%M" {
+		t.Errorf("Pattern: got [%s], expected [%s]", got, "%Y-%m-%d %H:%M")
+	}
+	if len(f.appenders) != 1 {
+		t.Errorf("Default specifications should compile to one Appender, got %d", len(f.appenders))
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tm := time.Date(2006, time.January, 2, 15, 4, 5, 123456789, time.UTC)
+
+	tests := []struct {
+		pattern  string
+		options  []Option
+		expected string
+	}{
+		{"%H:%M:%S.%L", []Option{WithMilliseconds('L')}, "15:04:05.123"},
+		{"%H:%M:%S.%f", []Option{WithMicroseconds('f')}, "15:04:05.123456"},
+		{"%s", []Option{WithUnixSeconds('s')}, "1136214245"},
+		{"%Q", []Option{WithSpecification('Q', Verbatim("100%"))}, "100%"},
+		{"%G", []Option{WithSpecification('G', StdlibFormat("Jan _2"))}, "Jan  2"},
+		{"%o", []Option{WithSpecification('o', StrftimeFormat("%o %B"))}, "2nd January"},
+		{"[%J]", []Option{WithSpecification('J', AppendFunc(func(b []byte, t time.Time) []byte {
+			return append(b, strings.ToUpper(t.Weekday().String())...)
+		}))}, "[MONDAY]"},
+		{"%A %B %p", []Option{WithLocale(&strftime.Locale{
+			WeekdaysFull: []string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+			MonthsFull: []string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio",
+				"agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+			AM: "a. m.",
+			PM: "p. m.",
+		})}, "lunes enero p. m."},
+	}
+
+	for _, tt := range tests {
+		got, err := Format(tt.pattern, tm, tt.options...)
+		if err != nil {
+			t.Errorf("Format(%q) returned error: %v", tt.pattern, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("Format(%q): got [%s], expected [%s]", tt.pattern, got, tt.expected)
+		}
+	}
+
+	// The default set is left unchanged
+	if _, err := New("%L"); err == nil {
+		t.Error("WithMilliseconds should not change the default specification set")
+	}
+}
+
+func TestSpecificationSet(t *testing.T) {
+	tm := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+
+	ds := NewSpecificationSet()
+	if err := ds.Set('Y', StdlibFormat("06")); err != nil {
+		t.Fatal(err)
+	}
+	if err := ds.Delete('y'); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Format("%Y-%m", tm, WithSpecificationSet(ds), WithMilliseconds('L'))
+	if err != nil || got != "06-01" {
+		t.Errorf("Format with a custom set: got [%s] (%v), expected [%s]", got, err, "06-01")
+	}
+	if _, err := Format("%y", tm, WithSpecificationSet(ds)); err == nil {
+		t.Error("A deleted specification should not be found")
+	}
+	// WithSpecification changes the given set
+	if _, err := ds.Lookup('L'); err != nil {
+		t.Errorf("WithMilliseconds should add to the given set: %v", err)
+	}
+	if err := ds.Set('x', nil); err == nil {
+		t.Error("Set should reject a nil Appender")
+	}
+}
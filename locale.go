@@ -1,5 +1,10 @@
 package strftime
 
+import (
+	"cmp"
+	"time"
+)
+
 // Locale defines the date and time names required for locale settings
 type Locale struct {
 	WeekdaysFull   []string // Full names (starting from Sunday)
@@ -8,8 +13,49 @@ type Locale struct {
 	MonthsAbbrev   []string // Abbreviated month names
 	AM             string   // AM identifier
 	PM             string   // PM identifier
+
+	// Strftime patterns the composite specifiers expand to, the POSIX default is used when empty
+	DateTimeFormat string // %c, default "%a %b %-d %H:%M:%S %Y"
+	DateFormat     string // %x, default "%m/%d/%y"
+	TimeFormat     string // %X, default "%H:%M:%S"
+	TimeFormat12   string // %r, default "%I:%M:%S %p"
+	DateCmdFormat  string // %+, default "%a %b %-d %H:%M:%S %Z %Y"
+
+	// Alternative representations selected by the POSIX %E and %O modifiers
+	Eras              []Era    // Eras for %EC, %Ey and %EY, in any order; the plain specifiers are used outside them
+	EraDateTimeFormat string   // %Ec, %c is used when empty
+	EraDateFormat     string   // %Ex, %x is used when empty
+	EraTimeFormat     string   // %EX, %X is used when empty
+	AltDigits         []string // Digits for %O, either ten digit substitutes or one entry per value starting at 0
+
+	// Ordinal suffixes written by %o and the '*' flag, numbers are left unmarked when nil
+	Ordinal OrdinalFunc
+
+	// First day of the week counted by %K, Sunday by default
+	FirstWeekday time.Weekday
+
+	// Localized time zone names written by %:Z, %::Z and %:::Z
+	ZoneNames     map[string]ZoneName // Names keyed by CLDR metazone or IANA zone ID, see Metazones
+	GMTFormat     string              // Pattern written when a zone has no name, default "GMT%:z"
+	GMTZeroFormat string              // Pattern written for a zero offset when a zone has no name, default "GMT"
+
+	// Descriptions written by Explain, keyed as in EnglishDescriptions, which supplies missing entries
+	Descriptions map[string]string
 }
 
+// Default patterns of the composite specifiers
+const (
+	defaultDateTimeFormat = "%a %b %-d %H:%M:%S %Y"
+	defaultDateFormat     = "%m/%d/%y"
+	defaultTimeFormat     = "%H:%M:%S"
+	defaultTimeFormat12   = "%I:%M:%S %p"
+	defaultDateCmdFormat  = "%a %b %-d %H:%M:%S %Z %Y"
+)
+
+// maxCompositeDepth limits how deeply composite specifiers may expand into each other,
+// so that a locale pattern referring to itself cannot recurse forever
+const maxCompositeDepth = 4
+
 // Default English Locale
 var DefaultLocale = &Locale{
 	WeekdaysFull:   []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
@@ -18,7 +64,41 @@ var DefaultLocale = &Locale{
 		"January", "February", "March", "April", "May", "June",
 		"July", "August", "September", "October", "November", "December",
 	},
-	MonthsAbbrev: []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
-	AM:           "AM",
-	PM:           "PM",
+	MonthsAbbrev:   []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	AM:             "AM",
+	PM:             "PM",
+	DateTimeFormat: defaultDateTimeFormat,
+	DateFormat:     defaultDateFormat,
+	TimeFormat:     defaultTimeFormat,
+	TimeFormat12:   defaultTimeFormat12,
+	DateCmdFormat:  defaultDateCmdFormat,
+	Ordinal:        EnglishOrdinal,
+	ZoneNames:      EnglishZoneNames,
+}
+
+// composite returns the strftime pattern the composite specifier spec expands to
+func (l *Locale) composite(spec byte) string {
+	switch spec {
+	case 'c':
+		return cmp.Or(l.DateTimeFormat, defaultDateTimeFormat)
+	case 'x':
+		return cmp.Or(l.DateFormat, defaultDateFormat)
+	case 'X':
+		return cmp.Or(l.TimeFormat, defaultTimeFormat)
+	case 'r':
+		return cmp.Or(l.TimeFormat12, defaultTimeFormat12)
+	case '+':
+		return cmp.Or(l.DateCmdFormat, defaultDateCmdFormat)
+	case 'D':
+		return "%m/%d/%y"
+	case 'F':
+		return "%Y-%m-%d"
+	case 'R':
+		return "%H:%M"
+	case 'T':
+		return "%H:%M:%S"
+	case 'v':
+		return "%e-%b-%Y"
+	}
+	return ""
 }
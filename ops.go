@@ -0,0 +1,366 @@
+package strftime
+
+import (
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// opKind identifies how an op produces its output
+type opKind uint8
+
+const (
+	opLiteral   opKind = iota // Text copied verbatim
+	opNumber                  // Numeric field, optionally padded to a number of digits
+	opName                    // Name looked up in the locale
+	opLayout                  // Go reference layout passed to time.Time.AppendFormat
+	opComposite               // Strftime pattern expanded in place
+	opFraction                // Fractional seconds truncated to a number of digits
+	opOffset                  // UTC offset, digits holds the number of colons
+	opEra                     // Locale era name, year or full year, selected by field
+	opUnknown                 // Unknown or incomplete specifier, resolved by the UnknownSpecifierPolicy
+	opCustom                  // Specifier registered in a SpecifierSet
+	opZoneName                // Localized time zone name, digits holds the form
+)
+
+// op is a single formatting instruction, produced from a literal run or a conversion specifier
+type op struct {
+	kind     opKind
+	text     string     // Literal text, Go layout or composite format
+	sub      []op       // Precompiled ops of a composite format, nil if not compiled
+	field    field      // Numeric field for opNumber
+	names    nameTable  // Locale table for opName
+	digits   int        // Minimum digits for opNumber (0 means no padding), digits for opFraction
+	pad      byte       // Padding character for digits and width
+	width    int        // Field width the whole output is padded to, 0 means no padding
+	textCase caseMode   // Case conversion applied to the output
+	zulu     bool       // Write a zero UTC offset as "Z"
+	alt      bool       // Write opNumber with the locale's alternative digits
+	ordinal  bool       // Follow opNumber with the locale's ordinal suffix
+	sign     bool       // Write a '+' before non-negative opNumber values
+	custom   FormatFunc // Formatter of an opCustom specifier
+	mods     Modifiers  // Modifiers given to an opCustom specifier
+}
+
+// appendTo appends the output of the op for t to dst
+func (o *op) appendTo(dst []byte, t time.Time, opts *options) []byte {
+	start := len(dst)
+	dst = o.appendValue(dst, t, opts)
+	if o.textCase != caseNone {
+		dst = convertCase(dst, start, o.textCase)
+	}
+	if o.width > 0 {
+		dst = padLeft(dst, start, o.width, o.pad)
+	}
+	return dst
+}
+
+// appendValue appends the output of the op for t to dst, before case conversion and padding
+func (o *op) appendValue(dst []byte, t time.Time, opts *options) []byte {
+	switch o.kind {
+	case opLiteral:
+		return append(dst, o.text...)
+	case opNumber:
+		value := o.field.value(t, opts.locale)
+		if o.sign && value >= 0 {
+			dst = append(dst, '+')
+		}
+		if o.alt && len(opts.locale.AltDigits) > 0 {
+			dst = appendAltDigits(dst, value, o.digits, o.pad, opts.locale.AltDigits)
+		} else {
+			dst = appendInt(dst, value, o.digits, o.pad)
+		}
+		if o.ordinal {
+			dst = append(dst, opts.locale.ordinal(int(value), o.field == fieldDay)...)
+		}
+		return dst
+	case opName:
+		return append(dst, o.names.lookup(t, opts.locale)...)
+	case opLayout:
+		return t.AppendFormat(dst, o.text)
+	case opComposite:
+		if o.sub == nil {
+			if opts.depth >= maxCompositeDepth {
+				return dst
+			}
+			nested := *opts
+			nested.depth++
+			return appendFormat(dst, o.text, t, &nested)
+		}
+		for k := range o.sub {
+			dst = o.sub[k].appendTo(dst, t, opts)
+		}
+		return dst
+	case opFraction:
+		return appendFraction(dst, t.Nanosecond(), o.digits)
+	case opEra:
+		return o.appendEra(dst, t, opts)
+	case opOffset:
+		_, offset := t.Zone()
+		return appendOffset(dst, offset, o.digits, o.zulu)
+	case opCustom:
+		return o.custom(dst, t, opts.locale, o.mods)
+	case opZoneName:
+		if name := opts.locale.zoneName(t, o.digits); name != "" {
+			return append(dst, name...)
+		}
+		// Zones without a name are written in the locale's GMT format
+		if opts.depth >= maxCompositeDepth {
+			return dst
+		}
+		nested := *opts
+		nested.depth++
+		return appendFormat(dst, opts.locale.gmtFormat(t), t, &nested)
+	}
+	return dst
+}
+
+// appendEra appends the era name (fieldCentury), the year within the era (fieldYear2)
+// or the era's full year pattern (fieldYear); outside any era the field itself is used
+func (o *op) appendEra(dst []byte, t time.Time, opts *options) []byte {
+	e := opts.locale.eraAt(t)
+	if e == nil {
+		return appendInt(dst, o.field.value(t, opts.locale), o.digits, o.pad)
+	}
+	switch o.field {
+	case fieldCentury:
+		return append(dst, e.Name...)
+	case fieldYear2:
+		return appendInt(dst, int64(e.year(t.Year())), o.digits, o.pad)
+	}
+	if opts.depth >= maxCompositeDepth {
+		return dst
+	}
+	nested := *opts
+	nested.depth++
+	return appendFormat(dst, e.format(), t, &nested)
+}
+
+// plain reports whether the op is free of case conversion and field width,
+// so that it can be merged with or expanded into its neighbours
+func (o op) plain() bool {
+	return o.textCase == caseNone && o.width == 0
+}
+
+// caseMode selects the case conversion applied by the '^' and '#' flags
+type caseMode uint8
+
+const (
+	caseNone  caseMode = iota
+	caseUpper          // '^': uppercase
+	caseSwap           // '#': opposite case, lowercase if there is no lowercase letter, uppercase otherwise
+)
+
+// convertCase applies mode to dst[start:] using Unicode case mapping
+func convertCase(dst []byte, start int, mode caseMode) []byte {
+	seg := dst[start:]
+	upper := true
+	if mode == caseSwap {
+		upper = false
+		for _, r := range string(seg) {
+			if unicode.IsLower(r) {
+				upper = true
+				break
+			}
+		}
+	}
+
+	// ASCII text is converted in place
+	ascii := true
+	for _, c := range seg {
+		if c >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		for k, c := range seg {
+			if upper && 'a' <= c && c <= 'z' {
+				seg[k] = c - 'a' + 'A'
+			} else if !upper && 'A' <= c && c <= 'Z' {
+				seg[k] = c - 'A' + 'a'
+			}
+		}
+		return dst
+	}
+
+	// Case mapping can change the encoded length, so rebuild the segment from a copy
+	var buf [64]byte
+	src := append(buf[:0], seg...)
+	dst = dst[:start]
+	for len(src) > 0 {
+		r, size := utf8.DecodeRune(src)
+		src = src[size:]
+		if upper {
+			r = unicode.ToUpper(r)
+		} else {
+			r = unicode.ToLower(r)
+		}
+		dst = utf8.AppendRune(dst, r)
+	}
+	return dst
+}
+
+// padLeft pads dst[start:] on the left with pad until it is at least width characters long
+func padLeft(dst []byte, start, width int, pad byte) []byte {
+	n := width - utf8.RuneCount(dst[start:])
+	if n <= 0 {
+		return dst
+	}
+	end := len(dst)
+	for k := 0; k < n; k++ {
+		dst = append(dst, pad)
+	}
+	copy(dst[start+n:], dst[start:end])
+	for k := start; k < start+n; k++ {
+		dst[k] = pad
+	}
+	return dst
+}
+
+// field identifies a numeric component of a time
+type field uint8
+
+const (
+	fieldCentury     field = iota // Year / 100
+	fieldYear                     // Year with century
+	fieldYear2                    // Year without century
+	fieldISOYear                  // ISO 8601 week-based year
+	fieldISOYear2                 // ISO 8601 week-based year without century
+	fieldISOWeek                  // ISO 8601 week number
+	fieldWeekSunday               // Week of year, weeks starting on Sunday (0-53)
+	fieldWeekMonday               // Week of year, weeks starting on Monday (0-53)
+	fieldMonth                    // Month (1-12)
+	fieldDay                      // Day of month (1-31)
+	fieldYearDay                  // Day of year (1-366)
+	fieldHour                     // Hour in 24h format (0-23)
+	fieldHour12                   // Hour in 12h format (1-12)
+	fieldMinute                   // Minute (0-59)
+	fieldSecond                   // Second (0-59)
+	fieldWeekday                  // Weekday (0-6, Sunday is 0)
+	fieldWeekdayISO               // Weekday (1-7, Monday is 1)
+	fieldUnix                     // Seconds since the Unix epoch
+	fieldQuarter                  // Quarter of the year (1-4)
+	fieldHalf                     // Half of the year (1-2)
+	fieldWeekOfMonth              // Week of month, the first week holds the 1st (1-6)
+	fieldQuarterDay               // Day of quarter (1-92)
+)
+
+// value extracts the field from t, loc supplies the first day of the week for fieldWeekOfMonth
+func (f field) value(t time.Time, loc *Locale) int64 {
+	switch f {
+	case fieldCentury:
+		return floorDiv(int64(t.Year()), 100)
+	case fieldYear:
+		return int64(t.Year())
+	case fieldYear2:
+		return int64(t.Year()) - floorDiv(int64(t.Year()), 100)*100
+	case fieldISOYear:
+		year, _ := t.ISOWeek()
+		return int64(year)
+	case fieldISOYear2:
+		year, _ := t.ISOWeek()
+		return int64(year) - floorDiv(int64(year), 100)*100
+	case fieldISOWeek:
+		_, week := t.ISOWeek()
+		return int64(week)
+	case fieldWeekSunday:
+		return int64(weekOfYear(t.YearDay()-1, int(t.Weekday())))
+	case fieldWeekMonday:
+		return int64(weekOfYear(t.YearDay()-1, (int(t.Weekday())+6)%7))
+	case fieldMonth:
+		return int64(t.Month())
+	case fieldDay:
+		return int64(t.Day())
+	case fieldYearDay:
+		return int64(t.YearDay())
+	case fieldHour:
+		return int64(t.Hour())
+	case fieldHour12:
+		hour := t.Hour() % 12
+		if hour == 0 {
+			hour = 12
+		}
+		return int64(hour)
+	case fieldMinute:
+		return int64(t.Minute())
+	case fieldSecond:
+		return int64(t.Second())
+	case fieldWeekday:
+		return int64(t.Weekday())
+	case fieldWeekdayISO:
+		wd := int64(t.Weekday())
+		if wd == 0 {
+			wd = 7 // Sunday should be 7 in this format
+		}
+		return wd
+	case fieldUnix:
+		return t.Unix()
+	case fieldQuarter:
+		return int64(t.Month()-1)/3 + 1
+	case fieldHalf:
+		return int64(t.Month()-1)/6 + 1
+	case fieldWeekOfMonth:
+		return int64(weekOfMonth(t.Day(), t.Weekday(), loc.FirstWeekday))
+	case fieldQuarterDay:
+		first := time.Date(t.Year(), (t.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+		return int64(t.YearDay() - first.YearDay() + 1)
+	}
+	return 0
+}
+
+// weekOfYear returns the POSIX week number for the zero-based day of year yday.
+// offset is the number of days since the first day of the week, so days before
+// the first such weekday of the year fall into week 0.
+func weekOfYear(yday, offset int) int {
+	return (yday + 7 - offset) / 7
+}
+
+// floorDiv divides a by b rounding towards negative infinity, so that years before 0 keep
+// a century and two-digit year that add back up to the year, as -45 is century -1 and year 55
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b < 0 {
+		q--
+	}
+	return q
+}
+
+// weekOfMonth returns the week of month of day, which falls on weekday, with weeks starting on first.
+// The week holding the 1st of the month is week 1.
+func weekOfMonth(day int, weekday, first time.Weekday) int {
+	// Days between the start of the week and the 1st of the month
+	offset := (int(weekday) - (day-1)%7 - int(first) + 14) % 7
+	return (day-1+offset)/7 + 1
+}
+
+// nameTable identifies a list of names carried by a Locale
+type nameTable uint8
+
+const (
+	nameWeekdayFull nameTable = iota
+	nameWeekdayAbbrev
+	nameMonthFull
+	nameMonthAbbrev
+	nameAMPM
+)
+
+// lookup returns the name for t from the table in loc
+func (n nameTable) lookup(t time.Time, loc *Locale) string {
+	switch n {
+	case nameWeekdayFull:
+		return loc.WeekdaysFull[t.Weekday()]
+	case nameWeekdayAbbrev:
+		return loc.WeekdaysAbbrev[t.Weekday()]
+	case nameMonthFull:
+		return loc.MonthsFull[t.Month()-1]
+	case nameMonthAbbrev:
+		return loc.MonthsAbbrev[t.Month()-1]
+	case nameAMPM:
+		if t.Hour() < 12 {
+			return loc.AM
+		}
+		return loc.PM
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package strftime
+
+// Option configures formatting and parsing
+type Option func(*options)
+
+// options holds the settings collected from Option values
+type options struct {
+	locale     *Locale
+	fraction   FractionMode
+	zulu       bool
+	unknown    UnknownSpecifierPolicy
+	specifiers *SpecifierSet
+	yearDigits int
+	dialect    Dialect
+
+	depth int // Number of composite specifiers being expanded, internal to formatting and parsing
+}
+
+// FractionMode controls how parsing handles fractional second digits beyond the specifier's precision
+type FractionMode int
+
+const (
+	FractionTruncate FractionMode = iota // Drop the extra digits (default)
+	FractionRound                        // Round half up on the first extra digit
+)
+
+// UnknownSpecifierPolicy controls what formatting does with unknown or incomplete specifiers, such as %Q or a trailing %
+type UnknownSpecifierPolicy int
+
+const (
+	UnknownSpecifierChar     UnknownSpecifierPolicy = iota // Write the bare character ("Q") and drop a trailing "%" (default)
+	UnknownSpecifierVerbatim                               // Write the specifier as it appears in the format ("%Q")
+	UnknownSpecifierDrop                                   // Write nothing
+	UnknownSpecifierError                                  // Report a *FormatError
+)
+
+// WithLocale sets the locale, nil selects DefaultLocale
+func WithLocale(loc *Locale) Option {
+	return func(o *options) {
+		o.locale = loc
+	}
+}
+
+// WithFractionMode sets how %f, %L and %N handle input digits beyond their precision when parsing
+func WithFractionMode(mode FractionMode) Option {
+	return func(o *options) {
+		o.fraction = mode
+	}
+}
+
+// WithZuluUTC makes %z and its colon variants write a zero UTC offset as "Z", as RFC 3339 does
+func WithZuluUTC() Option {
+	return func(o *options) {
+		o.zulu = true
+	}
+}
+
+// WithUnknownSpecifierPolicy sets how unknown or incomplete specifiers are formatted
+func WithUnknownSpecifierPolicy(policy UnknownSpecifierPolicy) Option {
+	return func(o *options) {
+		o.unknown = policy
+	}
+}
+
+// WithExpandedYears makes %Y and %G write ISO 8601 expanded years, with a sign and at least digits digits,
+// such as +012345 and -0044 for 6 and 4 digits. Parsing then expects the year in the same form.
+func WithExpandedYears(digits int) Option {
+	return func(o *options) {
+		o.yearDigits = digits
+	}
+}
+
+// newOptions applies opts on top of the defaults
+func newOptions(opts []Option) options {
+	o := options{locale: DefaultLocale}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.locale == nil {
+		o.locale = DefaultLocale
+	}
+	return o
+}
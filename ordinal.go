@@ -0,0 +1,61 @@
+package strftime
+
+// OrdinalFunc returns the suffix that turns n into an ordinal, such as "st" for 1 in English.
+// day reports whether n is a day of the month, which some languages only mark on the first day.
+type OrdinalFunc func(n int, day bool) string
+
+// EnglishOrdinal writes 1st, 2nd, 3rd, 4th, 11th, 12th, 13th, 21st and so on
+func EnglishOrdinal(n int, _ bool) string {
+	if n < 0 {
+		n = -n
+	}
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
+
+// FrenchOrdinal writes 1er and 2e, but leaves days other than the first unmarked as in "le 2 mars"
+func FrenchOrdinal(n int, day bool) string {
+	switch {
+	case n == 1:
+		return "er"
+	case day:
+		return ""
+	}
+	return "e"
+}
+
+// SpanishOrdinal writes 1.º and 2.º, but leaves days other than the first unmarked as in "2 de marzo"
+func SpanishOrdinal(n int, day bool) string {
+	if day && n != 1 {
+		return ""
+	}
+	return ".º"
+}
+
+// GermanOrdinal writes 1., 2. and so on
+func GermanOrdinal(int, bool) string {
+	return "."
+}
+
+// DutchOrdinal writes 1e, 2e and so on
+func DutchOrdinal(int, bool) string {
+	return "e"
+}
+
+// ordinal returns the locale's ordinal suffix for n, or "" if the locale has no ordinal rule
+func (l *Locale) ordinal(n int, day bool) string {
+	if l.Ordinal == nil {
+		return ""
+	}
+	return l.Ordinal(n, day)
+}
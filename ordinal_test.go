@@ -0,0 +1,146 @@
+package strftime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOrdinalRules(t *testing.T) {
+	tests := []struct {
+		rule     OrdinalFunc
+		n        int
+		day      bool
+		expected string
+	}{
+		{EnglishOrdinal, 1, true, "st"},
+		{EnglishOrdinal, 2, true, "nd"},
+		{EnglishOrdinal, 3, true, "rd"},
+		{EnglishOrdinal, 4, true, "th"},
+		{EnglishOrdinal, 11, true, "th"},
+		{EnglishOrdinal, 12, true, "th"},
+		{EnglishOrdinal, 13, true, "th"},
+		{EnglishOrdinal, 21, true, "st"},
+		{EnglishOrdinal, 22, true, "nd"},
+		{EnglishOrdinal, 23, true, "rd"},
+		{EnglishOrdinal, 111, false, "th"},
+		{EnglishOrdinal, 122, false, "nd"},
+		{FrenchOrdinal, 1, true, "er"},
+		{FrenchOrdinal, 2, true, ""},
+		{FrenchOrdinal, 2, false, "e"},
+		{SpanishOrdinal, 1, true, ".º"},
+		{SpanishOrdinal, 2, true, ""},
+		{SpanishOrdinal, 2, false, ".º"},
+		{GermanOrdinal, 3, true, "."},
+		{DutchOrdinal, 3, true, "e"},
+	}
+	for _, tt := range tests {
+		if suffix := tt.rule(tt.n, tt.day); suffix != tt.expected {
+			t.Errorf("Ordinal(%d, %v): got [%s], expected [%s]", tt.n, tt.day, suffix, tt.expected)
+		}
+	}
+}
+
+func TestFormatOrdinal(t *testing.T) {
+	withRule := func(rule OrdinalFunc) *Locale {
+		l := *DefaultLocale
+		l.Ordinal = rule
+		return &l
+	}
+	french := withRule(FrenchOrdinal)
+	german := withRule(GermanOrdinal)
+	dutch := withRule(DutchOrdinal)
+	spanish := withRule(SpanishOrdinal)
+	none := withRule(nil)
+
+	first := time.Date(2025, time.February, 1, 9, 5, 7, 0, time.UTC)
+	tests := []struct {
+		format   string
+		t        time.Time
+		locale   *Locale
+		expected string
+	}{
+		{"%B %o", first, DefaultLocale, "February 1st"},
+		{"%B %o", time.Date(2025, time.February, 2, 0, 0, 0, 0, time.UTC), DefaultLocale, "February 2nd"},
+		{"%B %o", time.Date(2025, time.February, 23, 0, 0, 0, 0, time.UTC), DefaultLocale, "February 23rd"},
+		{"%B %o", time.Date(2025, time.February, 12, 0, 0, 0, 0, time.UTC), DefaultLocale, "February 12th"},
+		{"%*d", first, DefaultLocale, "1st"},
+		{"%^o", first, DefaultLocale, "1ST"},
+		{"%3o", first, DefaultLocale, "001st"},
+		{"%_3o", first, DefaultLocale, "  1st"},
+		{"%*j day", first, DefaultLocale, "32nd day"},
+		{"%*U week", first, DefaultLocale, "4th week"},
+		{"%*V", first, DefaultLocale, "5th"},
+		{"%*m", first, DefaultLocale, "2nd"},
+		{"le %o", first, french, "le 1er"},
+		{"le %o", time.Date(2025, time.February, 2, 0, 0, 0, 0, time.UTC), french, "le 2"},
+		{"%*j jour", first, french, "32e jour"},
+		{"%o de", first, spanish, "1.º de"},
+		{"%o", first, german, "1."},
+		{"%*W", first, dutch, "4e"},
+		{"%o", first, none, "1"},
+	}
+	for _, tt := range tests {
+		formatted := StrftimeL(tt.format, tt.t, tt.locale)
+		if formatted != tt.expected {
+			t.Errorf("StrftimeL(%q): got [%s], expected [%s]", tt.format, formatted, tt.expected)
+		}
+		f := MustCompile(tt.format, WithLocale(tt.locale))
+		if formatted := f.Format(tt.t); formatted != tt.expected {
+			t.Errorf("Compiled %q: got [%s], expected [%s]", tt.format, formatted, tt.expected)
+		}
+	}
+}
+
+func TestParseOrdinal(t *testing.T) {
+	french := *DefaultLocale
+	french.Ordinal = FrenchOrdinal
+
+	tests := []struct {
+		format   string
+		input    string
+		locale   *Locale
+		expected time.Time
+	}{
+		{"%B %o, %Y", "February 1st, 2025", DefaultLocale, time.Date(2025, time.February, 1, 0, 0, 0, 0, time.Local)},
+		{"%B %o, %Y", "February 23rd, 2025", DefaultLocale, time.Date(2025, time.February, 23, 0, 0, 0, 0, time.Local)},
+		{"%Y-%m-%*d", "2025-02-12th", DefaultLocale, time.Date(2025, time.February, 12, 0, 0, 0, 0, time.Local)},
+		{"%*j day of %Y", "32nd day of 2025", DefaultLocale, time.Date(2025, time.February, 1, 0, 0, 0, 0, time.Local)},
+		{"%Y %j", "2024 366", DefaultLocale, time.Date(2024, time.December, 31, 0, 0, 0, 0, time.Local)},
+		{"le %o %m %Y", "le 1er 02 2025", &french, time.Date(2025, time.February, 1, 0, 0, 0, 0, time.Local)},
+		{"le %o %m %Y", "le 2 02 2025", &french, time.Date(2025, time.February, 2, 0, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		parsed, err := ParseL(tt.format+" %H:%M:%S", tt.input+" 00:00:00", tt.locale)
+		if err != nil {
+			t.Errorf("ParseL(%q, %q) returned error: %v", tt.format, tt.input, err)
+			continue
+		}
+		if !parsed.Equal(tt.expected) {
+			t.Errorf("ParseL(%q, %q): got [%v], expected [%v]", tt.format, tt.input, parsed, tt.expected)
+		}
+	}
+
+	for _, input := range []string{"February 1nd, 2025", "February 1, 2025", "February st, 2025"} {
+		if _, err := ParseL("%B %o, %Y", input, DefaultLocale); err == nil {
+			t.Errorf("ParseL(%q) expected error, but got none", input)
+		}
+	}
+	if _, err := ParseL("%Y %j", "2025 367", DefaultLocale); err == nil {
+		t.Error("ParseL with day of year 367 expected error, but got none")
+	}
+}
+
+func TestOrdinalRegistered(t *testing.T) {
+	// %o came after SpecifierSet, so a binding of 'o' takes precedence
+	set := NewSpecifierSet()
+	if err := set.Register('o', sprintFormat, nil); err != nil {
+		t.Fatalf("Register('o') returned error: %v", err)
+	}
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+	if formatted := StrftimeWith("%o", testTime, WithSpecifiers(set)); formatted != "03" {
+		t.Errorf("StrftimeWith with registered %%o: got [%s], expected [03]", formatted)
+	}
+	if formatted := StrftimeWith("%o", testTime); formatted != "3rd" {
+		t.Errorf("StrftimeWith without set: got [%s], expected [3rd]", formatted)
+	}
+}
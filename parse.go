@@ -3,6 +3,7 @@ package strftime
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,9 +15,40 @@ type parseResult struct {
 	hour    int
 	minute  int
 	second  int
+	nsec    int
 	hour12  bool // Whether to use 12-hour format (%I)
 	ampmSet bool // Whether %p (AM/PM marker) appeared
 	isPM    bool // Whether it is PM when using 12-hour format
+
+	weekday    time.Weekday // Day of week from %A, %a, %u or %w
+	weekdaySet bool         // Whether a day of week appeared
+	week       int          // Week of year from %U or %W
+	weekStart  time.Weekday // First day of the week counted by week
+	weekSet    bool         // Whether %U or %W appeared
+	yearDay    int          // Day of year from %j
+	yearDaySet bool         // Whether %j appeared
+	isoYear    int          // ISO 8601 week-based year from %G or %g
+	isoYearSet bool         // Whether %G or %g appeared
+	isoWeek    int          // ISO 8601 week number from %V
+	isoWeekSet bool         // Whether %V appeared
+
+	monthSet bool // Whether a month was parsed
+	daySet   bool // Whether a day of month or of year was parsed
+
+	quarter        int  // Quarter from %q
+	quarterSet     bool // Whether %q appeared
+	half           int  // Half-year from %J
+	halfSet        bool // Whether %J appeared
+	weekOfMonth    int  // Week of month from %K
+	weekOfMonthSet bool // Whether %K appeared
+	quarterDay     int  // Day of quarter from %i
+	quarterDaySet  bool // Whether %i appeared
+
+	loc *time.Location // Location from %z or %Z, nil if none was parsed
+
+	era        *Era // Era from %EC, nil if none was parsed
+	eraYear    int  // Year within the era from %Ey
+	eraYearSet bool // Whether %Ey appeared
 }
 
 // parseFixedInt reads a fixed-length numeric string from s[pos:] and returns the corresponding integer and new position
@@ -50,19 +82,255 @@ func parseIntVariable(s string, pos, minDigits, maxDigits int) (int, int, error)
 	return val, pos, nil
 }
 
+// parseFraction reads the digits of a fractional second from s[pos:] and returns it in nanoseconds.
+// Only the first precision digits (at most 9) are kept; the rest are truncated or rounded according to mode.
+// Rounding may yield a full second, which time.Date normalizes.
+func parseFraction(s string, pos, precision int, mode FractionMode) (int, int, error) {
+	start := pos
+	for pos < len(s) && s[pos] >= '0' && s[pos] <= '9' {
+		pos++
+	}
+	if pos == start {
+		return 0, start, fmt.Errorf("expected fractional second digits at position %d", start)
+	}
+	digits := s[start:pos]
+	precision = min(precision, 9)
+
+	nsec, unit := 0, 1000000000
+	for k := 0; k < precision; k++ {
+		unit /= 10
+		if k < len(digits) {
+			nsec += int(digits[k]-'0') * unit
+		}
+	}
+	if mode == FractionRound && len(digits) > precision && digits[precision] >= '5' {
+		nsec += unit
+	}
+	return nsec, pos, nil
+}
+
+// parseNumber reads a number of the given digits from s[pos:].
+// The '-' and '_' padding flags allow fewer digits, and '_' also allows leading spaces.
+func parseNumber(s string, pos, digits int, pad byte) (int, int, error) {
+	switch pad {
+	case '_':
+		for pos < len(s) && s[pos] == ' ' {
+			pos++
+		}
+		return parseIntVariable(s, pos, 1, digits)
+	case '-':
+		return parseIntVariable(s, pos, 1, digits)
+	}
+	return parseFixedInt(s, pos, digits)
+}
+
+// parseYear reads a year of the given digits from s[pos:], with an optional sign.
+// A signed year may have more digits, as ISO 8601 expanded years do, unless expanded
+// requires the sign and fixes the number of digits as WithExpandedYears does.
+func parseYear(s string, pos, digits int, pad byte, expanded int) (int, int, error) {
+	if pad == '_' {
+		for pos < len(s) && s[pos] == ' ' {
+			pos++
+		}
+	}
+	if pos >= len(s) || (s[pos] != '+' && s[pos] != '-') {
+		if expanded > 0 {
+			return 0, pos, fmt.Errorf("expected signed year at position %d", pos)
+		}
+		return parseNumber(s, pos, digits, pad)
+	}
+
+	var value, end int
+	var err error
+	switch {
+	case expanded > 0:
+		value, end, err = parseIntVariable(s, pos+1, expanded, expanded)
+	case pad == '-' || pad == '_':
+		value, end, err = parseIntVariable(s, pos+1, 1, 9)
+	default:
+		value, end, err = parseIntVariable(s, pos+1, digits, 9)
+	}
+	if err != nil {
+		return 0, pos, err
+	}
+	if s[pos] == '-' {
+		value = -value
+	}
+	return value, end, nil
+}
+
+// parseAltDigits reads a number of the given digits written with the locale's alternative digits.
+// A list of ten digit substitutes is read digit by digit, also accepting ASCII digits; longer lists
+// are matched by their longest entry. Input in neither form is read as ASCII digits.
+func parseAltDigits(s string, pos, digits int, pad byte, alt []string) (int, int, error) {
+	if len(alt) != 10 {
+		value, end := -1, pos
+		for k, a := range alt {
+			if a != "" && strings.HasPrefix(s[pos:], a) && pos+len(a) > end {
+				value, end = k, pos+len(a)
+			}
+		}
+		if value < 0 {
+			return parseNumber(s, pos, digits, pad)
+		}
+		return value, end, nil
+	}
+
+	if pad == '_' {
+		for pos < len(s) && s[pos] == ' ' {
+			pos++
+		}
+	}
+	start, value, count := pos, 0, 0
+digits:
+	for count < digits && pos < len(s) {
+		if s[pos] >= '0' && s[pos] <= '9' {
+			value = value*10 + int(s[pos]-'0')
+			pos++
+			count++
+			continue
+		}
+		for d, a := range alt {
+			if a != "" && strings.HasPrefix(s[pos:], a) {
+				value = value*10 + d
+				pos += len(a)
+				count++
+				continue digits
+			}
+		}
+		break
+	}
+	if count == 0 || (count < digits && pad != '-' && pad != '_') {
+		return 0, start, fmt.Errorf("expected %d digits at position %d", digits, start)
+	}
+	return value, pos, nil
+}
+
+// parseEraName reads the longest era name from s[pos:]
+func parseEraName(s string, pos int, eras []Era) (*Era, int, error) {
+	var found *Era
+	for k := range eras {
+		e := &eras[k]
+		if e.Name != "" && strings.HasPrefix(s[pos:], e.Name) && (found == nil || len(e.Name) > len(found.Name)) {
+			found = e
+		}
+	}
+	if found == nil {
+		return nil, pos, fmt.Errorf("failed to parse era name at position %d", pos)
+	}
+	return found, pos + len(found.Name), nil
+}
+
+// parseEraYear reads a full era year as written by %EY, trying the pattern of each era in turn
+func parseEraYear(result *parseResult, s string, pos int, o *options) (int, error) {
+	if o.depth >= maxCompositeDepth {
+		return pos, fmt.Errorf("composite specifier %%EY nested too deeply")
+	}
+	nested := *o
+	nested.depth++
+	for k := range o.locale.Eras {
+		attempt := *result
+		j, err := parseInto(&attempt, o.locale.Eras[k].format(), s, pos, &nested)
+		if err == nil {
+			*result = attempt
+			return j, nil
+		}
+	}
+	return pos, fmt.Errorf("failed to parse era year at position %d", pos)
+}
+
+// parseOffset reads a UTC offset from s[pos:] and returns a matching fixed-offset location.
+// It accepts "Z" and ±hh, ±hhmm, ±hh:mm, ±hhmmss and ±hh:mm:ss up to 23:59:59; a zero offset yields time.UTC.
+func parseOffset(s string, pos int) (*time.Location, int, error) {
+	if pos < len(s) && s[pos] == 'Z' {
+		return time.UTC, pos + 1, nil
+	}
+	if pos >= len(s) || (s[pos] != '+' && s[pos] != '-') {
+		return nil, pos, fmt.Errorf("expected UTC offset at position %d", pos)
+	}
+	sign := 1
+	if s[pos] == '-' {
+		sign = -1
+	}
+	j := pos + 1
+
+	hours, j, err := parseFixedInt(s, j, 2)
+	if err != nil {
+		return nil, pos, err
+	}
+	// Minutes and seconds are optional and use the same separator
+	var parts [2]int
+	colon := j < len(s) && s[j] == ':'
+	for k := range parts {
+		next := j
+		if colon {
+			if next >= len(s) || s[next] != ':' {
+				break
+			}
+			next++
+		}
+		if next+2 > len(s) || s[next] < '0' || s[next] > '9' {
+			break
+		}
+		parts[k], j, err = parseFixedInt(s, next, 2)
+		if err != nil {
+			return nil, pos, err
+		}
+	}
+	minutes, seconds := parts[0], parts[1]
+	if hours > 23 || minutes > 59 || seconds > 59 {
+		return nil, pos, fmt.Errorf("invalid UTC offset %q at position %d", s[pos:j], pos)
+	}
+
+	offset := sign * (hours*3600 + minutes*60 + seconds)
+	if offset == 0 {
+		return time.UTC, j, nil
+	}
+	return time.FixedZone("", offset), j, nil
+}
+
 // ParseL parses the input string s according to the specified format and locale, and returns a time.Time object.
 // Supported conversion specifiers include:
 //
-//	%Y,%y,%m,%d,%e,%H,%I,%M,%S,%p,%D,%F,%B,%b,%h,%A,%a, and %%.
+//	%Y,%y,%G,%g,%V,%m,%d,%e,%o,%j,%q,%J,%K,%i,%H,%I,%M,%S,%f,%L,%N,%p,%D,%F,%R,%T,%c,%x,%X,%r,%+,%B,%b,%h,%A,%a,%u,%w,%U,%W,%Z,%z,%:z,%::z,%:::z, and %%.
 //
-// For POSIX extensions (e.g., starting with %E or %O), the extension prefix is skipped, and formats like "%EY" and "%E%Y" are supported.
+// The composite specifiers %c, %x, %X, %r and %+ are parsed through the locale's pattern for them.
+// The '-' and '_' flags allow numeric fields with fewer digits, as formatting produces them.
+// %o and numeric fields with the '*' flag expect the locale's ordinal suffix after the number.
+//
+// %q and %J without a month select the first day of the quarter or half-year, and %i counts days
+// from the start of the quarter. %K selects a day together with the day of week, or the locale's
+// FirstWeekday if none was parsed.
+//
+// Each form of %z accepts "Z" and any of the offset forms the others produce,
+// and the returned time is in a matching fixed-offset location.
+//
+// %f, %L and %N accept any number of digits. Digits beyond their precision (6, 3 and 9, or the width
+// given as in %3N) are truncated; use ParseWith and WithFractionMode to round them instead.
+//
+// %U and %W resolve to a date together with the year and a day of week (%A, %a, %u or %w);
+// without a day of week the first day of the week is used. %V does the same with the ISO 8601
+// week-based year from %G or %g, or the year if neither was parsed; %G and %g without %V set the year.
+//
+// With a locale that has eras, %EC, %Ey and %EY read era names and years and resolve them to Gregorian years,
+// and %Ec, %Ex and %EX are parsed through the locale's era patterns. With alternative digits, %O numeric
+// specifiers read them. Otherwise the %E and %O prefixes are skipped.
+// Formats are read with the grammar of Tokenize, shared with formatting, so "%E%Y" stands for "%EY".
 func ParseL(format, s string, locale *Locale) (time.Time, error) {
-	if locale == nil {
-		locale = DefaultLocale
-	}
+	return ParseWith(format, s, WithLocale(locale))
+}
 
-	// Use the current time as the default value, parts not parsed will use the corresponding parts of the current time
+// ParseWith parses the input string s according to the specified format and options.
+// It supports the same conversion specifiers as ParseL.
+func ParseWith(format, s string, opts ...Option) (time.Time, error) {
+	o := newOptions(opts)
+
+	// Use the current time as the default value, parts not parsed will use the corresponding parts of the current time.
+	// CPython's strptime uses 1900-01-01 00:00:00 instead, and returns a naive time, taken here as UTC.
 	base := time.Now()
+	if o.dialect == DialectPython {
+		base = time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
 	result := parseResult{
 		year:    base.Year(),
 		month:   int(base.Month()),
@@ -75,196 +343,394 @@ func ParseL(format, s string, locale *Locale) (time.Time, error) {
 		isPM:    false,
 	}
 
-	i, j := 0, 0
+	j, err := parseInto(&result, format, s, 0, &o)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// Skip trailing whitespace characters in the input string, CPython doesn't allow any
+	for o.dialect != DialectPython && j < len(s) && (s[j] == ' ' || s[j] == '\t') {
+		j++
+	}
+	if j != len(s) {
+		return time.Time{}, fmt.Errorf("unparsed trailing characters at position %d", j)
+	}
+
+	return result.resolve(base, &o)
+}
+
+// resolve combines the parsed fields into a time, taking the fields that weren't parsed from base
+func (result *parseResult) resolve(base time.Time, o *options) (time.Time, error) {
+	// For 12-hour format, %p must be used; CPython takes a missing %p as AM
+	if result.hour12 && !result.ampmSet && o.dialect != DialectPython {
+		return time.Time{}, fmt.Errorf("12-hour format specified but missing AM/PM marker")
+	}
+
+	// Adjust based on 12-hour format and AM/PM
+	if result.hour12 {
+		if result.hour < 1 || result.hour > 12 {
+			return time.Time{}, fmt.Errorf("invalid hour %d for 12-hour format", result.hour)
+		}
+		if result.isPM && result.hour != 12 {
+			result.hour += 12
+		} else if !result.isPM && result.hour == 12 {
+			result.hour = 0
+		}
+	}
+
+	// An era year is counted from the parsed era, or from the current one if no era name was given
+	if result.eraYearSet {
+		era := result.era
+		if era == nil {
+			era = o.locale.eraAt(base)
+		}
+		if era != nil {
+			result.year = era.Start.Year() + result.eraYear - era.Offset
+		}
+	}
+
+	// A quarter or half-year without a month selects its first month, and its first day unless a day was given
+	if !result.monthSet && (result.quarterSet || result.halfSet) {
+		if result.quarterSet {
+			result.month = (result.quarter-1)*3 + 1
+		} else {
+			result.month = (result.half-1)*6 + 1
+		}
+		if !result.daySet {
+			result.day = 1
+		}
+	}
+
+	// A day of year must fall within the parsed year, which has 365 or 366 days
+	if result.yearDaySet {
+		if days := time.Date(result.year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay(); result.yearDay > days {
+			return time.Time{}, fmt.Errorf("invalid day of year %d for %d", result.yearDay, result.year)
+		}
+	}
+
+	// A day of quarter counts from the first day of the month's quarter
+	if result.quarterDaySet {
+		result.month = (result.month-1)/3*3 + 1
+		result.day = result.quarterDay
+		start := time.Date(result.year, time.Month(result.month), 1, 0, 0, 0, 0, time.UTC)
+		if days := int(start.AddDate(0, 3, 0).Sub(start).Hours() / 24); result.day > days {
+			return time.Time{}, fmt.Errorf("invalid day of quarter %d", result.quarterDay)
+		}
+	}
+
+	// A week of month selects the day together with the day of week, the locale's first weekday by default
+	if result.weekOfMonthSet {
+		weekday := o.locale.FirstWeekday
+		if result.weekdaySet {
+			weekday = result.weekday
+		}
+		first := time.Date(result.year, time.Month(result.month), 1, 0, 0, 0, 0, time.UTC).Weekday()
+		offset := (int(first) - int(o.locale.FirstWeekday) + 7) % 7
+		result.day = 1 - offset + (result.weekOfMonth-1)*7 + (int(weekday)-int(o.locale.FirstWeekday)+7)%7
+		if result.day < 1 || result.day > time.Date(result.year, time.Month(result.month)+1, 0, 0, 0, 0, 0, time.UTC).Day() {
+			return time.Time{}, fmt.Errorf("week %d of month %d has no %s", result.weekOfMonth, result.month, weekday)
+		}
+	}
+
+	// An ISO 8601 week selects the date together with the week-based year and the day of week, Monday by default.
+	// A week-based year without a week is taken as the year.
+	if result.isoYearSet {
+		result.year = result.isoYear
+	}
+	if result.isoWeekSet {
+		weekday := time.Monday
+		if result.weekdaySet {
+			weekday = result.weekday
+		}
+		result.month = 1
+		result.day = 1 + isoWeekYearDay(result.year, result.isoWeek, weekday)
+	}
+
+	// A week number selects the date together with the year and the day of week
+	if result.weekSet {
+		weekday := result.weekStart
+		if result.weekdaySet {
+			weekday = result.weekday
+		}
+		result.month = 1
+		result.day = 1 + weekYearDay(result.year, result.week, weekday, result.weekStart)
+	}
+
+	loc := base.Location()
+	if result.loc != nil {
+		loc = result.loc
+	}
+	parsedTime := time.Date(result.year, time.Month(result.month), result.day, result.hour, result.minute, result.second, result.nsec, loc)
+	return parsedTime, nil
+}
+
+// weekYearDay returns the zero-based day of year of weekday in the given week of year,
+// where weeks start on weekStart and days before the first weekStart belong to week 0.
+// The result may fall outside the year, which time.Date normalizes.
+func weekYearDay(year, week int, weekday, weekStart time.Weekday) int {
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC).Weekday()
+	first := (int(weekStart) - int(jan1) + 7) % 7
+	offset := (int(weekday) - int(weekStart) + 7) % 7
+	return first + (week-1)*7 + offset
+}
+
+// isoWeekYearDay returns the zero-based day of year of weekday in the given ISO 8601 week of the week-based year.
+// Week 1 holds January 4th, so the result may fall outside the year, which time.Date normalizes.
+func isoWeekYearDay(year, week int, weekday time.Weekday) int {
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC).Weekday()
+	monday := 3 - (int(jan4)+6)%7
+	return monday + (week-1)*7 + (int(weekday)+6)%7
+}
+
+// parseInto parses s[j:] according to format into result and returns the position after the parsed input
+func parseInto(result *parseResult, format, s string, j int, o *options) (int, error) {
+	locale := o.locale
+	fold := o.dialect == DialectPython // CPython matches names and literals regardless of case
+	i := 0
 	// Traverse the format string
 	for i < len(format) {
 		if format[i] == '%' {
-			i++ // Skip '%'
-			if i >= len(format) {
-				return time.Time{}, fmt.Errorf("incomplete format specifier at end")
+			tok, d := scanToken(format, i, o)
+			i = tok.End
+			if tok.Specifier == "" {
+				if tok.Alt != 0 {
+					return j, fmt.Errorf("incomplete format specifier after posix extension")
+				}
+				return j, fmt.Errorf("incomplete format specifier at end")
 			}
-			// Check for POSIX extension prefix %E or %O
-			if format[i] == 'E' || format[i] == 'O' {
-				i++ // Skip extension marker
-				// If followed by a '%', skip it as well (support "%E%Y" format)
-				if i < len(format) && format[i] == '%' {
-					i++
+			// Only the padding flags affect parsing, apart from the ordinal suffix
+			mods := tok.Modifiers()
+			pad, width, alt, ordinal := mods.Pad, mods.Width, mods.Alt, mods.Ordinal
+			spec := tok.Specifier[0]
+
+			// CPython reads numbers other than years with one digit or more, and days with a leading space
+			if o.dialect == DialectPython && pad == 0 {
+				switch spec {
+				case 'd':
+					pad = '_'
+				case 'm', 'H', 'I', 'M', 'S', 'j', 'U', 'W':
+					pad = '-'
 				}
-				if i >= len(format) {
-					return time.Time{}, fmt.Errorf("incomplete format specifier after posix extension")
+			}
+
+			// Custom specifiers, built-in ones take precedence
+			if d.kind == opCustom {
+				custom, _, _ := o.specifier(tok.Specifier)
+				mods.Pad = pad
+				var err error
+				j, err = parseCustom(result, custom, tok.Specifier, s, j, mods, locale)
+				if err != nil {
+					return j, err
 				}
+				continue
 			}
-			// Get the conversion specifier character and increment the pointer
-			spec := format[i]
-			i++
-			switch spec {
-			case 'Y': // 4-digit year
-				result.year, j, _ = parseFixedInt(s, j, 4)
-			case 'y': // 2-digit year, converted to 1900s or 2000s by convention
-				var twoDigit int
-				twoDigit, j, _ = parseFixedInt(s, j, 2)
-				if twoDigit < 69 {
-					result.year = 2000 + twoDigit
-				} else {
-					result.year = 1900 + twoDigit
+
+			def := lookupSpec(spec)
+			if tok.Colons > 0 && (def == nil || tok.Colons > def.parseColons) {
+				return j, fmt.Errorf("unsupported conversion specifier: %s", tok.Text)
+			}
+
+			p := fieldParser{result: result, s: s, j: j, o: o, def: def, spec: spec, pad: pad, width: width, alt: alt, ordinal: ordinal, fold: fold}
+			var err error
+			switch {
+			case alt == 'E' && len(locale.Eras) > 0 && def != nil && def.parseEra != nil:
+				// Era based representations fall back to the plain specifiers when the locale has no eras
+				err = def.parseEra(&p)
+			case def == nil || def.parse == nil:
+				return j, fmt.Errorf("unsupported conversion specifier: %%%c", spec)
+			default:
+				err = def.parse(&p)
+			}
+			j = p.j
+			if err != nil {
+				return j, err
+			}
+		} else {
+			// CPython matches whitespace in the format with one or more whitespace characters
+			if o.dialect == DialectPython && isSpace(format[i]) {
+				for i < len(format) && isSpace(format[i]) {
+					i++
 				}
-			case 'm': // Month (two digits)
-				result.month, j, _ = parseFixedInt(s, j, 2)
-			case 'd': // Day (two digits)
-				result.day, j, _ = parseFixedInt(s, j, 2)
-			case 'e': // Day (1-2 digits, leading space may exist)
-				if j < len(s) && s[j] == ' ' {
+				start := j
+				for j < len(s) && isSpace(s[j]) {
 					j++
 				}
-				result.day, j, _ = parseIntVariable(s, j, 1, 2)
-			case 'H': // 24-hour format hour
-				result.hour, j, _ = parseFixedInt(s, j, 2)
-			case 'I': // 12-hour format hour
-				result.hour, j, _ = parseFixedInt(s, j, 2)
-				result.hour12 = true
-			case 'M': // Minute
-				result.minute, j, _ = parseFixedInt(s, j, 2)
-			case 'S': // Second
-				result.second, j, _ = parseFixedInt(s, j, 2)
-			case 'p': // AM/PM marker
-				if len(s[j:]) >= len(locale.AM) && s[j:j+len(locale.AM)] == locale.AM {
-					result.ampmSet = true
-					result.isPM = false
-					j += len(locale.AM)
-				} else if len(s[j:]) >= len(locale.PM) && s[j:j+len(locale.PM)] == locale.PM {
-					result.ampmSet = true
-					result.isPM = true
-					j += len(locale.PM)
-				} else {
-					return time.Time{}, fmt.Errorf("expected AM/PM marker at position %d", j)
-				}
-			case 'D':
-				// "%D" equals "%m/%d/%y"
-				result.month, j, _ = parseFixedInt(s, j, 2)
-				if j >= len(s) || s[j] != '/' {
-					return time.Time{}, fmt.Errorf("expected '/' after month in %%D")
-				}
-				j++
-				result.day, j, _ = parseFixedInt(s, j, 2)
-				if j >= len(s) || s[j] != '/' {
-					return time.Time{}, fmt.Errorf("expected '/' after day in %%D")
-				}
-				j++
-				var twoDigit int
-				twoDigit, j, _ = parseFixedInt(s, j, 2)
-				if twoDigit < 69 {
-					result.year = 2000 + twoDigit
-				} else {
-					result.year = 1900 + twoDigit
-				}
-			case 'F': // Equivalent to "%Y-%m-%d"
-				result.year, j, _ = parseFixedInt(s, j, 4)
-				if j >= len(s) || s[j] != '-' {
-					return time.Time{}, fmt.Errorf("expected '-' after year in %%F")
-				}
-				j++
-				result.month, j, _ = parseFixedInt(s, j, 2)
-				if j >= len(s) || s[j] != '-' {
-					return time.Time{}, fmt.Errorf("expected '-' after month in %%F")
-				}
-				j++
-				result.day, j, _ = parseFixedInt(s, j, 2)
-			case 'B': // Full month name (based on locale.MonthsFull)
-				found := false
-				for iMonth, mName := range locale.MonthsFull {
-					if len(s[j:]) >= len(mName) && s[j:j+len(mName)] == mName {
-						result.month = iMonth + 1
-						j += len(mName)
-						found = true
-						break
-					}
-				}
-				if !found {
-					return time.Time{}, fmt.Errorf("failed to parse full month name at position %d", j)
-				}
-			case 'b', 'h': // Abbreviated month name (based on locale.MonthsAbbrev)
-				found := false
-				for iMonth, mName := range locale.MonthsAbbrev {
-					if len(s[j:]) >= len(mName) && s[j:j+len(mName)] == mName {
-						result.month = iMonth + 1
-						j += len(mName)
-						found = true
-						break
-					}
-				}
-				if !found {
-					return time.Time{}, fmt.Errorf("failed to parse abbreviated month name at position %d", j)
-				}
-			case 'A': // Full weekday name (consumed but does not affect values)
-				found := false
-				for _, wName := range locale.WeekdaysFull {
-					if len(s[j:]) >= len(wName) && s[j:j+len(wName)] == wName {
-						j += len(wName)
-						found = true
-						break
-					}
-				}
-				if !found {
-					return time.Time{}, fmt.Errorf("failed to parse full weekday name at position %d", j)
-				}
-			case 'a': // Abbreviated weekday name (consumed but does not affect values)
-				found := false
-				for _, wName := range locale.WeekdaysAbbrev {
-					if len(s[j:]) >= len(wName) && s[j:j+len(wName)] == wName {
-						j += len(wName)
-						found = true
-						break
-					}
-				}
-				if !found {
-					return time.Time{}, fmt.Errorf("failed to parse abbreviated weekday name at position %d", j)
+				if j == start {
+					return j, fmt.Errorf("expected whitespace at position %d", j)
 				}
-			case '%': // Literal '%'
-				if j >= len(s) || s[j] != '%' {
-					return time.Time{}, fmt.Errorf("expected literal '%%' at position %d", j)
-				}
-				j++
-			default:
-				// For unknown conversion specifiers, output '%' and the character as is
-				return time.Time{}, fmt.Errorf("unsupported conversion specifier: %%%c", spec)
+				continue
 			}
-		} else {
 			// Non-conversion specifier part, requires literal match
-			if j >= len(s) || s[j] != format[i] {
-				return time.Time{}, fmt.Errorf("literal mismatch at position %d: expected '%c', got '%c'", j, format[i], s[j])
+			if j >= len(s) {
+				return j, fmt.Errorf("literal mismatch at position %d: expected '%c', got end of input", j, format[i])
+			}
+			if s[j] != format[i] && !(fold && hasPrefix(s[j:j+1], format[i:i+1], true)) {
+				return j, fmt.Errorf("literal mismatch at position %d: expected '%c', got '%c'", j, format[i], s[j])
 			}
 			i++
 			j++
 		}
 	}
 
-	// Skip trailing whitespace characters in the input string
-	for j < len(s) && (s[j] == ' ' || s[j] == '\t') {
-		j++
+	return j, nil
+}
+
+// fieldParser holds the state of ParseL while it reads one built-in specifier with the parse functions of specDefs
+type fieldParser struct {
+	result  *parseResult
+	s       string
+	j       int // Position in s, advanced past the input read
+	o       *options
+	def     *specDef
+	spec    byte
+	pad     byte
+	width   int
+	alt     byte
+	ordinal bool
+	fold    bool // Whether names and literals are matched regardless of case
+}
+
+// number reads a numeric field of the given digits, honoring the padding flags and %O digits
+func (p *fieldParser) number(digits int) (int, error) {
+	locale := p.o.locale
+	var value int
+	var err error
+	if !p.ordinal {
+		if p.alt == 'O' && len(locale.AltDigits) > 0 {
+			value, p.j, err = parseAltDigits(p.s, p.j, digits, p.pad, locale.AltDigits)
+		} else {
+			value, p.j, err = parseNumber(p.s, p.j, digits, p.pad)
+		}
+		return value, err
 	}
-	if j != len(s) {
-		return time.Time{}, fmt.Errorf("unparsed trailing characters at position %d", j)
+	// Ordinals are unpadded unless a width is given, and followed by the locale's suffix
+	numPad := byte('-')
+	if p.width > 0 {
+		digits, numPad = p.width, p.pad
+	}
+	if p.alt == 'O' && len(locale.AltDigits) > 0 {
+		value, p.j, err = parseAltDigits(p.s, p.j, digits, numPad, locale.AltDigits)
+	} else {
+		value, p.j, err = parseNumber(p.s, p.j, digits, numPad)
 	}
+	if err != nil {
+		return value, err
+	}
+	suffix := locale.ordinal(value, p.spec == 'd' || p.spec == 'e' || p.spec == 'o')
+	if !strings.HasPrefix(p.s[p.j:], suffix) {
+		return value, fmt.Errorf("expected ordinal suffix %q at position %d", suffix, p.j)
+	}
+	p.j += len(suffix)
+	return value, nil
+}
 
-	// For 12-hour format, %p must be used
-	if result.hour12 && !result.ampmSet {
-		return time.Time{}, fmt.Errorf("12-hour format specified but missing AM/PM marker")
+// bounded reads a numeric field of the given digits that must lie within the range of the specifier
+func (p *fieldParser) bounded(digits int) (int, error) {
+	value, err := p.number(digits)
+	if err != nil {
+		return value, err
+	}
+	if value < p.def.min || value > p.def.max {
+		return value, fmt.Errorf("invalid value %d for %%%c", value, p.spec)
 	}
+	return value, nil
+}
 
-	// Adjust based on 12-hour format and AM/PM
-	if result.hour12 {
-		if result.hour < 1 || result.hour > 12 {
-			return time.Time{}, fmt.Errorf("invalid hour %d for 12-hour format", result.hour)
-		}
-		if result.isPM && result.hour != 12 {
-			result.hour += 12
-		} else if !result.isPM && result.hour == 12 {
-			result.hour = 0
+// year reads a full year, signed if it is negative or expanded
+func (p *fieldParser) year() (int, error) {
+	if p.alt == 'O' || p.ordinal {
+		return p.number(4)
+	}
+	var value int
+	var err error
+	value, p.j, err = parseYear(p.s, p.j, max(p.width, 4), p.pad, p.o.yearDigits)
+	return value, err
+}
+
+// name reads one of names and returns its index, what names the kind of name in errors
+func (p *fieldParser) name(names []string, what string) (int, error) {
+	for k, name := range names {
+		if hasPrefix(p.s[p.j:], name, p.fold) {
+			p.j += len(name)
+			return k, nil
 		}
 	}
+	return 0, fmt.Errorf("failed to parse %s at position %d", what, p.j)
+}
+
+// fraction reads fractional seconds with any number of digits, keeping precision digits unless a width is given
+func (p *fieldParser) fraction(precision int) error {
+	if p.width > 0 {
+		precision = p.width
+	}
+	start := p.j
+	var err error
+	p.result.nsec, p.j, err = parseFraction(p.s, p.j, precision, p.o.fraction)
+	if err != nil {
+		return err
+	}
+	if p.o.dialect == DialectPython && p.spec == 'f' && p.j-start > 6 {
+		p.j = start
+		return fmt.Errorf("expected at most 6 digits for %%f at position %d", start)
+	}
+	return nil
+}
 
-	parsedTime := time.Date(result.year, time.Month(result.month), result.day, result.hour, result.minute, result.second, 0, base.Location())
-	return parsedTime, nil
+// composite reads pattern, the expansion of the composite specifier name
+func (p *fieldParser) composite(pattern, name string) error {
+	if p.o.depth >= maxCompositeDepth {
+		return fmt.Errorf("composite specifier %s nested too deeply", name)
+	}
+	nested := *p.o
+	nested.depth++
+	var err error
+	p.j, err = parseInto(p.result, pattern, p.s, p.j, &nested)
+	return err
+}
+
+// twoDigitYear converts a year without century to 1969-2068
+func twoDigitYear(value int) int {
+	if value < 69 {
+		return 2000 + value
+	}
+	return 1900 + value
+}
+
+// parseCustom reads the custom specifier name from s[j:] with its ParseFunc and stores the fields it sets in result
+func parseCustom(result *parseResult, spec Specifier, name, s string, j int, mods Modifiers, locale *Locale) (int, error) {
+	if spec.Parse == nil {
+		return j, fmt.Errorf("custom specifier %%%s cannot be parsed", name)
+	}
+	fields := Fields{
+		Year:       result.year,
+		Month:      result.month,
+		Day:        result.day,
+		Hour:       result.hour,
+		Minute:     result.minute,
+		Second:     result.second,
+		Nanosecond: result.nsec,
+		Location:   result.loc,
+	}
+	n, err := spec.Parse(s[j:], &fields, locale, mods)
+	if err != nil {
+		return j, err
+	}
+	if n < 0 || n > len(s)-j {
+		return j, fmt.Errorf("custom specifier %%%s consumed %d bytes at position %d", name, n, j)
+	}
+	result.year = fields.Year
+	if fields.Month != result.month {
+		result.month, result.monthSet = fields.Month, true
+	}
+	if fields.Day != result.day {
+		result.day, result.daySet = fields.Day, true
+	}
+	result.hour = fields.Hour
+	result.minute = fields.Minute
+	result.second = fields.Second
+	result.nsec = fields.Nanosecond
+	result.loc = fields.Location
+	return j + n, nil
 }
 
 // Parse parses the string using the default locale
@@ -1,6 +1,7 @@
 package strftime
 
 import (
+	"cmp"
 	"strings"
 	"testing"
 	"time"
@@ -393,3 +394,374 @@ func TestParse_AbbrevWeekday_Default(t *testing.T) {
 		t.Errorf("Expected year to be 2025, got %d", tme.Year())
 	}
 }
+
+func TestParse_WeekNumbers(t *testing.T) {
+	tests := []struct {
+		format   string
+		input    string
+		expected string
+	}{
+		{"%Y %U %a", "2023 01 Sun", "2023-01-01"},
+		{"%Y %W %a", "2024 01 Mon", "2024-01-01"},
+		{"%Y %W %a", "2024 53 Tue", "2024-12-31"},
+		{"%Y %U %A", "2025 00 Wednesday", "2025-01-01"},
+		{"%Y %U %w", "2025 01 0", "2025-01-05"},
+		{"%Y %W %u", "2025 01 1", "2025-01-06"},
+		{"%Y %U %u", "2028 53 7", "2028-12-31"},
+		{"%Y %W %u", "2027 52 5", "2027-12-31"},
+		{"%Y %U", "2025 10", "2025-03-09"}, // Without a weekday the week's Sunday is used
+		{"%Y %W", "2025 10", "2025-03-10"}, // Without a weekday the week's Monday is used
+	}
+
+	for _, tt := range tests {
+		parsedTime, err := Parse(tt.format, tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q, %q) returned error: %v", tt.format, tt.input, err)
+			continue
+		}
+		if got := parsedTime.Format("2006-01-02"); got != tt.expected {
+			t.Errorf("Parse(%q, %q): got %s, expected %s", tt.format, tt.input, got, tt.expected)
+		}
+		// Formatting the parsed time must give back the input
+		if formatted := Strftime(tt.format, parsedTime); formatted != tt.input {
+			t.Errorf("Round trip of %q: got [%s], expected [%s]", tt.format, formatted, tt.input)
+		}
+	}
+}
+
+func TestParse_WeekNumberErrors(t *testing.T) {
+	inputs := []struct {
+		format string
+		input  string
+	}{
+		{"%Y %U", "2025 54"},
+		{"%Y %W", "2025 x1"},
+		{"%Y %U %u", "2025 01 8"},
+		{"%Y %U %w", "2025 01 7"},
+	}
+	for _, in := range inputs {
+		if _, err := Parse(in.format, in.input); err == nil {
+			t.Errorf("Parse(%q, %q) expected error, but got none", in.format, in.input)
+		}
+	}
+}
+
+func TestParse_YearDay(t *testing.T) {
+	tests := []struct {
+		format   string
+		input    string
+		expected string
+	}{
+		{"%Y %j", "2025 001", "2025-01-01"},
+		{"%Y %j", "2025 365", "2025-12-31"},
+		{"%Y %j", "2024 366", "2024-12-31"},
+		{"%j %Y", "060 2024", "2024-02-29"}, // The year may follow the day of year
+	}
+	for _, tt := range tests {
+		parsedTime, err := Parse(tt.format, tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q, %q) returned error: %v", tt.format, tt.input, err)
+			continue
+		}
+		if got := parsedTime.Format("2006-01-02"); got != tt.expected {
+			t.Errorf("Parse(%q, %q): got %s, expected %s", tt.format, tt.input, got, tt.expected)
+		}
+	}
+
+	// Day 366 only exists in leap years
+	for _, input := range []string{"2025 366", "1900 366", "2024 367", "2025 000"} {
+		if _, err := Parse("%Y %j", input); err == nil {
+			t.Errorf("Parse(%%Y %%j, %q) expected error, but got none", input)
+		}
+	}
+}
+
+func TestParse_FractionalSeconds(t *testing.T) {
+	tests := []struct {
+		format string
+		input  string
+		mode   FractionMode
+		nsec   int
+		second int
+	}{
+		{"%S.%f", "07.123456", FractionTruncate, 123456000, 7},
+		{"%S.%f", "07.5", FractionTruncate, 500000000, 7},
+		{"%S.%f", "07.1234567", FractionTruncate, 123456000, 7},
+		{"%S.%f", "07.1234567", FractionRound, 123457000, 7},
+		{"%S.%L", "07.123", FractionTruncate, 123000000, 7},
+		{"%S.%L", "07.1235", FractionRound, 124000000, 7},
+		{"%S.%N", "07.123456789", FractionTruncate, 123456789, 7},
+		{"%S.%N", "07.1234567891", FractionRound, 123456789, 7},
+		{"%S.%3N", "07.123456", FractionTruncate, 123000000, 7},
+		{"%S.%3N", "07.9996", FractionRound, 0, 8}, // Rounding carries into the seconds
+		{"%S.%6N", "07.12", FractionTruncate, 120000000, 7},
+	}
+
+	for _, tt := range tests {
+		parsedTime, err := ParseWith(tt.format, tt.input, WithFractionMode(tt.mode))
+		if err != nil {
+			t.Errorf("ParseWith(%q, %q) returned error: %v", tt.format, tt.input, err)
+			continue
+		}
+		if parsedTime.Nanosecond() != tt.nsec || parsedTime.Second() != tt.second {
+			t.Errorf("ParseWith(%q, %q, %v): got %d.%09d, expected %d.%09d", tt.format, tt.input, tt.mode,
+				parsedTime.Second(), parsedTime.Nanosecond(), tt.second, tt.nsec)
+		}
+	}
+
+	if _, err := Parse("%S.%f", "07."); err == nil {
+		t.Error("Expected error for missing fractional digits, but got none")
+	}
+
+	// Default ParseL truncates
+	parsedTime, err := ParseL("%H:%M:%S.%L", "09:05:07.1239", nil)
+	if err != nil {
+		t.Fatalf("ParseL returned error: %v", err)
+	}
+	if parsedTime.Nanosecond() != 123000000 {
+		t.Errorf("ParseL fractional seconds: got %d, expected 123000000", parsedTime.Nanosecond())
+	}
+}
+
+func TestParse_Offsets(t *testing.T) {
+	tests := []struct {
+		format string
+		input  string
+		offset int
+	}{
+		{"%H:%M%z", "10:00+0530", 5*3600 + 30*60},
+		{"%H:%M%z", "10:00-04:00", -4 * 3600},
+		{"%H:%M%:z", "10:00+05:30", 5*3600 + 30*60},
+		{"%H:%M%:z", "10:00Z", 0},
+		{"%H:%M%::z", "10:00-00:17:30", -(17*60 + 30)},
+		{"%H:%M%:::z", "10:00-04", -4 * 3600},
+		{"%H:%M%:::z", "10:00+05:30", 5*3600 + 30*60},
+		{"%H:%M %z", "10:00 -001730", -(17*60 + 30)},
+		{"%H:%M %z", "10:00 +0000", 0},
+		{"%H:%M%:z", "10:00-23:59", -(23*3600 + 59*60)},
+	}
+
+	for _, tt := range tests {
+		parsedTime, err := Parse(tt.format, tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q, %q) returned error: %v", tt.format, tt.input, err)
+			continue
+		}
+		_, offset := parsedTime.Zone()
+		if offset != tt.offset || parsedTime.Hour() != 10 {
+			t.Errorf("Parse(%q, %q): got %v, expected 10:00 at offset %d", tt.format, tt.input, parsedTime, tt.offset)
+		}
+	}
+
+	// A zero offset is returned in UTC
+	parsedTime, _ := Parse("%H:%M%z", "10:00Z")
+	if parsedTime.Location() != time.UTC {
+		t.Errorf("Expected UTC location for Z, got %v", parsedTime.Location())
+	}
+
+	for _, input := range []string{"10:00", "10:00+5", "10:00+05:60", "10:00 0530", "10:00+99:00", "10:00-2400"} {
+		if _, err := Parse("%H:%M%z", input); err == nil {
+			t.Errorf("Parse(%%z, %q) expected error, but got none", input)
+		}
+	}
+	if _, err := Parse("%H:%M%:Y", "10:002025"); err == nil {
+		t.Error("Expected error for colons before a specifier other than z, but got none")
+	}
+}
+
+func TestParse_OffsetRoundTrip(t *testing.T) {
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.FixedZone("", -(3*3600+30*60)))
+	for _, format := range []string{"%F %H:%M:%S%z", "%F %H:%M:%S%:z", "%F %H:%M:%S%::z", "%F %H:%M:%S%:::z"} {
+		parsedTime, err := Parse(format, Strftime(format, testTime))
+		if err != nil {
+			t.Errorf("Round trip of %q returned error: %v", format, err)
+			continue
+		}
+		if !parsedTime.Equal(testTime) {
+			t.Errorf("Round trip of %q: got %v, expected %v", format, parsedTime, testTime)
+		}
+	}
+}
+
+func TestParse_LocaleComposites(t *testing.T) {
+	germanLocale := *DefaultLocale
+	germanLocale.MonthsFull = []string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"}
+	germanLocale.DateTimeFormat = "%-d. %B %Y %H:%M:%S"
+	germanLocale.DateFormat = "%d.%m.%Y"
+
+	expected := time.Date(2025, time.February, 5, 15, 30, 45, 0, time.UTC)
+	tests := []struct {
+		loc    *Locale
+		format string
+		input  string
+	}{
+		{DefaultLocale, "%c", "Wed Feb 5 15:30:45 2025"},
+		{DefaultLocale, "%x %X", "02/05/25 15:30:45"},
+		{DefaultLocale, "%x %r", "02/05/25 03:30:45 PM"},
+		{DefaultLocale, "%+", "Wed Feb 5 15:30:45 UTC 2025"},
+		{DefaultLocale, "%F %T", "2025-02-05 15:30:45"},
+		{DefaultLocale, "%F %R:%S", "2025-02-05 15:30:45"},
+		{&germanLocale, "%c", "5. Februar 2025 15:30:45"},
+		{&germanLocale, "%x %X", "05.02.2025 15:30:45"},
+	}
+
+	for _, tt := range tests {
+		parsedTime, err := ParseWith(tt.format, tt.input, WithLocale(tt.loc))
+		if err != nil {
+			t.Errorf("ParseWith(%q, %q) returned error: %v", tt.format, tt.input, err)
+			continue
+		}
+		parsedTime = time.Date(parsedTime.Year(), parsedTime.Month(), parsedTime.Day(),
+			parsedTime.Hour(), parsedTime.Minute(), parsedTime.Second(), 0, time.UTC)
+		if !parsedTime.Equal(expected) {
+			t.Errorf("ParseWith(%q, %q): got %v, expected %v", tt.format, tt.input, parsedTime, expected)
+		}
+		// The parsed time must format back to the input
+		if formatted := StrftimeL(tt.format, parsedTime, tt.loc); formatted != tt.input {
+			t.Errorf("Round trip of %q: got [%s], expected [%s]", tt.format, formatted, tt.input)
+		}
+	}
+
+	loopLocale := *DefaultLocale
+	loopLocale.DateFormat = "%x"
+	if _, err := ParseL("%x", "02/05/25", &loopLocale); err == nil {
+		t.Error("Expected error for a self-referencing locale pattern, but got none")
+	}
+}
+
+func TestParse_PaddingFlags(t *testing.T) {
+	parsedTime, err := Parse("%-d/%-m/%Y %_H:%-M", "5/2/2025  9:7")
+	if err != nil {
+		t.Fatalf("Parse with padding flags returned error: %v", err)
+	}
+	if parsedTime.Day() != 5 || parsedTime.Month() != time.February || parsedTime.Hour() != 9 || parsedTime.Minute() != 7 {
+		t.Errorf("Parse with padding flags: got %v", parsedTime)
+	}
+}
+
+func TestParse_CalendarPosition(t *testing.T) {
+	monday := *DefaultLocale
+	monday.FirstWeekday = time.Monday
+
+	tests := []struct {
+		format   string
+		input    string
+		locale   *Locale
+		expected string
+	}{
+		{"%Y-Q%q", "2025-Q1", DefaultLocale, "2025-01-01"},
+		{"%Y-Q%q", "2025-Q3", DefaultLocale, "2025-07-01"},
+		{"%Y H%J", "2025 H2", DefaultLocale, "2025-07-01"},
+		{"%Y-Q%q-%i", "2024-Q4-92", DefaultLocale, "2024-12-31"},
+		{"%Y-%m %i", "2025-05 01", DefaultLocale, "2025-04-01"},
+		{"%Y-Q%q %m/%d", "2025-Q1 02/20", DefaultLocale, "2025-02-20"},
+		{"%Y %B week %K", "2025 February week 4", DefaultLocale, "2025-02-16"},
+		{"%Y %B week %K %a", "2025 February week 4 Thu", DefaultLocale, "2025-02-20"},
+		{"%Y %B week %K", "2025 February week 4", &monday, "2025-02-17"},
+		{"%Y-Q%q week %K %A", "2025-Q2 week 1 Tuesday", DefaultLocale, "2025-04-01"},
+	}
+
+	for _, tt := range tests {
+		parsedTime, err := ParseL(tt.format, tt.input, tt.locale)
+		if err != nil {
+			t.Errorf("ParseL(%q, %q) returned error: %v", tt.format, tt.input, err)
+			continue
+		}
+		if got := parsedTime.Format("2006-01-02"); got != tt.expected {
+			t.Errorf("ParseL(%q, %q): got [%s], expected [%s]", tt.format, tt.input, got, tt.expected)
+		}
+	}
+
+	invalid := []struct {
+		format string
+		input  string
+	}{
+		{"%Y-Q%q", "2025-Q5"},
+		{"%Y H%J", "2025 H3"},
+		{"%Y-Q%q-%i", "2025-Q1-91"},
+		{"%Y %B week %K %a", "2025 February week 1 Thu"},
+		{"%Y %B week %K", "2025 February week 7"},
+	}
+	for _, tt := range invalid {
+		if _, err := ParseL(tt.format, tt.input, DefaultLocale); err == nil {
+			t.Errorf("ParseL(%q, %q) expected error, but got none", tt.format, tt.input)
+		}
+	}
+}
+
+func TestParse_NegativeAndExpandedYears(t *testing.T) {
+	tests := []struct {
+		format   string
+		input    string
+		opts     []Option
+		expected int
+		reformat string // Expected output of formatting the parsed time, the input if empty
+	}{
+		{"%Y-%m-%d", "-0044-03-15", nil, -44, ""},
+		{"%Y-%m-%d", "+012345-03-15", nil, 12345, "12345-03-15"},
+		{"%Y-%m-%d", "2025-03-15", nil, 2025, ""},
+		{"%-Y-%m-%d", "-44-03-15", nil, -44, ""},
+		{"%F", "-0044-03-15", nil, -44, ""},
+		{"%Y%m%d", "+0020250315", []Option{WithExpandedYears(6)}, 2025, ""},
+		{"%F", "-000044-03-15", []Option{WithExpandedYears(6)}, -44, ""},
+	}
+
+	for _, tt := range tests {
+		opts := append([]Option{WithLocale(DefaultLocale)}, tt.opts...)
+		parsedTime, err := ParseWith(tt.format, tt.input, opts...)
+		if err != nil {
+			t.Errorf("ParseWith(%q, %q) returned error: %v", tt.format, tt.input, err)
+			continue
+		}
+		if parsedTime.Year() != tt.expected || parsedTime.Month() != time.March || parsedTime.Day() != 15 {
+			t.Errorf("ParseWith(%q, %q): got %v, expected year %d", tt.format, tt.input, parsedTime, tt.expected)
+		}
+		// The parsed time must format back to the input
+		expected := cmp.Or(tt.reformat, tt.input)
+		if formatted := StrftimeWith(tt.format, parsedTime, tt.opts...); formatted != expected {
+			t.Errorf("Round trip of %q: got [%s], expected [%s]", tt.format, formatted, expected)
+		}
+	}
+
+	if _, err := ParseWith("%Y", "2025", WithExpandedYears(6)); err == nil {
+		t.Error("ParseWith with expanded years and an unsigned year expected error, but got none")
+	}
+	if _, err := ParseWith("%Y", "+2025", WithExpandedYears(6)); err == nil {
+		t.Error("ParseWith with expanded years and too few digits expected error, but got none")
+	}
+}
+
+func TestParse_ISOWeek(t *testing.T) {
+	tests := []struct {
+		format   string
+		input    string
+		expected string
+	}{
+		{"%G-W%V-%u", "2025-W01-1", "2024-12-30"},
+		{"%G-W%V-%u", "2020-W53-7", "2021-01-03"},
+		{"%G-W%V", "2026-W10", "2026-03-02"},
+		{"%g W%-V %a", "15 W1 Thu", "2015-01-01"},
+		{"%Y W%V %A", "2025 W20 Friday", "2025-05-16"},
+		{"%G %m/%d", "2024 02/29", "2024-02-29"},
+	}
+
+	for _, tt := range tests {
+		parsedTime, err := Parse(tt.format, tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q, %q) returned error: %v", tt.format, tt.input, err)
+			continue
+		}
+		if got := parsedTime.Format("2006-01-02"); got != tt.expected {
+			t.Errorf("Parse(%q, %q): got [%s], expected [%s]", tt.format, tt.input, got, tt.expected)
+		}
+		if got := Strftime(tt.format, parsedTime); got != tt.input {
+			t.Errorf("Round trip of %q: got [%s], expected [%s]", tt.format, got, tt.input)
+		}
+	}
+
+	for _, input := range []string{"2025-W00", "2025-W54", "2025-Wxx"} {
+		if _, err := Parse("%G-W%V", input); err == nil {
+			t.Errorf("Parse(%q) expected error", input)
+		}
+	}
+}
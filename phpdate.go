@@ -0,0 +1,161 @@
+package strftime
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// phpDateFormats maps PHP date() characters to the equivalent strftime format
+var phpDateFormats = map[byte]string{
+	'd': "%d",                       // Day of month, 2 digits
+	'D': "%a",                       // Abbreviated weekday name
+	'j': "%-d",                      // Day of month without leading zeros
+	'l': "%A",                       // Full weekday name
+	'N': "%u",                       // ISO 8601 weekday (1-7, Monday is 1)
+	'w': "%w",                       // Weekday (0-6, Sunday is 0)
+	'W': "%V",                       // ISO 8601 week number
+	'F': "%B",                       // Full month name
+	'm': "%m",                       // Month, 2 digits
+	'M': "%b",                       // Abbreviated month name
+	'n': "%-m",                      // Month without leading zeros
+	'o': "%G",                       // ISO 8601 week-based year
+	'Y': "%Y",                       // Year, at least 4 digits
+	'y': "%y",                       // Year, 2 digits
+	'a': "%#p",                      // Lowercase am or pm
+	'A': "%p",                       // Uppercase AM or PM
+	'g': "%-I",                      // Hour in 12h format without leading zeros
+	'G': "%-H",                      // Hour in 24h format without leading zeros
+	'h': "%I",                       // Hour in 12h format, 2 digits
+	'H': "%H",                       // Hour in 24h format, 2 digits
+	'i': "%M",                       // Minute, 2 digits
+	's': "%S",                       // Second, 2 digits
+	'u': "%f",                       // Microseconds
+	'v': "%L",                       // Milliseconds
+	'O': "%z",                       // Offset, +0200
+	'P': "%:z",                      // Offset, +02:00
+	'p': "%:Ez",                     // Offset, +02:00 or Z for UTC
+	'T': "%Z",                       // Time zone abbreviation
+	'U': "%s",                       // Seconds since the Unix epoch
+	'c': "%Y-%m-%dT%H:%M:%S%:z",     // ISO 8601 date and time
+	'r': "%a, %d %b %Y %H:%M:%S %z", // RFC 2822 date and time
+}
+
+// phpDateFunc formats a PHP date() character that has no strftime equivalent
+type phpDateFunc struct {
+	name   string // What the character writes, for translation errors
+	format func(dst []byte, t time.Time, loc *Locale) []byte
+}
+
+// phpDateFuncs holds the PHP date() characters without a strftime equivalent
+var phpDateFuncs = map[byte]phpDateFunc{
+	'S': {"the ordinal suffix of the day", func(dst []byte, t time.Time, loc *Locale) []byte {
+		return append(dst, loc.ordinal(t.Day(), true)...)
+	}},
+	'z': {"the day of year counted from 0", func(dst []byte, t time.Time, _ *Locale) []byte {
+		return appendInt(dst, int64(t.YearDay()-1), 0, '0')
+	}},
+	't': {"the number of days in the month", func(dst []byte, t time.Time, _ *Locale) []byte {
+		return appendInt(dst, int64(time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()), 0, '0')
+	}},
+	'L': {"the leap year flag", func(dst []byte, t time.Time, _ *Locale) []byte {
+		if time.Date(t.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay() == 366 {
+			return append(dst, '1')
+		}
+		return append(dst, '0')
+	}},
+	'X': {"the signed expanded year", func(dst []byte, t time.Time, _ *Locale) []byte {
+		if t.Year() >= 0 {
+			dst = append(dst, '+')
+		}
+		return appendInt(dst, int64(t.Year()), 4, '0')
+	}},
+	'x': {"the expanded year", func(dst []byte, t time.Time, _ *Locale) []byte {
+		if t.Year() >= 10000 {
+			dst = append(dst, '+')
+		}
+		return appendInt(dst, int64(t.Year()), 4, '0')
+	}},
+	'B': {"Swatch Internet time", func(dst []byte, t time.Time, _ *Locale) []byte {
+		// Beats are thousandths of the day in UTC+1
+		u := t.UTC()
+		seconds := (u.Hour()*3600 + u.Minute()*60 + u.Second() + 3600) % 86400
+		return appendInt(dst, int64(seconds*10/864), 3, '0')
+	}},
+	'e': {"the time zone identifier", func(dst []byte, t time.Time, _ *Locale) []byte {
+		return append(dst, t.Location().String()...)
+	}},
+	'I': {"the daylight saving time flag", func(dst []byte, t time.Time, _ *Locale) []byte {
+		if isDaylight(t) {
+			return append(dst, '1')
+		}
+		return append(dst, '0')
+	}},
+	'Z': {"the offset in seconds", func(dst []byte, t time.Time, _ *Locale) []byte {
+		_, offset := t.Zone()
+		return appendInt(dst, int64(offset), 0, '0')
+	}},
+}
+
+// PHPDate formats time like PHP's date(format), using the names and ordinal rule of the locale.
+// A backslash writes the next character literally, and characters without a meaning in date() are copied.
+func PHPDate(format string, t time.Time, loc *Locale) string {
+	if loc == nil {
+		loc = DefaultLocale
+	}
+	opts := options{locale: loc}
+	dst := make([]byte, 0, len(format)*4)
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if c == '\\' {
+			// A trailing backslash escapes nothing and is dropped
+			if i++; i < len(format) {
+				dst = append(dst, format[i])
+			}
+			continue
+		}
+		if spec, ok := phpDateFormats[c]; ok {
+			dst = appendFormat(dst, spec, t, &opts)
+		} else if f, ok := phpDateFuncs[c]; ok {
+			dst = f.format(dst, t, loc)
+		} else {
+			dst = append(dst, c)
+		}
+	}
+	return string(dst)
+}
+
+// FromPHPDate converts a PHP date() format to the equivalent strftime format. The ordinal suffix S
+// is translated together with the day before it, as in "jS" (%o), and backslash escapes become literal text.
+//
+// Characters without a strftime equivalent, such as t (days in the month) or L (leap year), are left out of
+// the result and listed in a *TranslationError. The rest of the format is still translated and returned with it.
+func FromPHPDate(format string) (string, error) {
+	t := translation{source: format}
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		switch {
+		case c == '\\':
+			if i++; i < len(format) {
+				writeLiteral(&t.out, format[i])
+			}
+		case (c == 'j' || c == 'd') && strings.HasPrefix(format[i+1:], "S"):
+			// The day with its ordinal suffix, padded to two digits for d
+			if c == 'j' {
+				t.out.WriteString("%o")
+			} else {
+				t.out.WriteString("%*2d")
+			}
+			i++
+		default:
+			if spec, ok := phpDateFormats[c]; ok {
+				t.out.WriteString(spec)
+			} else if f, ok := phpDateFuncs[c]; ok {
+				t.skip(i, i+1, fmt.Sprintf("%s has no strftime equivalent", f.name))
+			} else {
+				writeLiteral(&t.out, c)
+			}
+		}
+	}
+	return t.result()
+}
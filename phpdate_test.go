@@ -0,0 +1,136 @@
+package strftime
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPHPDate(t *testing.T) {
+	// The examples of PHP's date() documentation
+	tm := time.Date(2001, time.March, 10, 17, 16, 18, 0, time.FixedZone("MST", -7*3600))
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"F j, Y, g:i a", "March 10, 2001, 5:16 pm"},
+		{"m.d.y", "03.10.01"},
+		{"j, n, Y", "10, 3, 2001"},
+		{"Ymd", "20010310"},
+		{"h-i-s, j-m-y, it is w Day", "05-16-18, 10-03-01, 1631 1618 6 Satpm01"},
+		{`\i\t \i\s \t\h\e jS \d\a\y.`, "it is the 10th day."},
+		{"D M j G:i:s T Y", "Sat Mar 10 17:16:18 MST 2001"},
+		{`H:m:s \m \i\s\ \m\o\n\t\h`, "17:03:18 m is month"},
+		{"Y-m-d H:i:s", "2001-03-10 17:16:18"},
+		{"l, N W o z t L", "Saturday, 6 10 2001 68 31 0"},
+		{"c", "2001-03-10T17:16:18-07:00"},
+		{"r", "Sat, 10 Mar 2001 17:16:18 -0700"},
+		{"U", "984269778"},
+		{"A u v O P p e I Z B", "PM 000000 000 -0700 -07:00 -07:00 MST 0 -25200 052"},
+		{"X x", "+2001 2001"},
+		{`100% \`, "100% "},
+	}
+
+	for _, tt := range tests {
+		if got := PHPDate(tt.format, tm, nil); got != tt.expected {
+			t.Errorf("PHPDate(%q): got [%s], expected [%s]", tt.format, got, tt.expected)
+		}
+	}
+
+	suffixes := map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 22: "22nd", 31: "31st"}
+	for day, expected := range suffixes {
+		if got := PHPDate("jS", time.Date(2025, time.January, day, 0, 0, 0, 0, time.UTC), DefaultLocale); got != expected {
+			t.Errorf("PHPDate(\"jS\") on day %d: got [%s], expected [%s]", day, got, expected)
+		}
+	}
+
+	leap := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)
+	if got := PHPDate("t L z p", leap, nil); got != "29 1 59 Z" {
+		t.Errorf("PHPDate in a leap year: got [%s], expected [29 1 59 Z]", got)
+	}
+
+	french := *DefaultLocale
+	french.MonthsFull = []string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"}
+	french.Ordinal = FrenchOrdinal
+	if got := PHPDate("jS F Y", time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC), &french); got != "1er mai 2025" {
+		t.Errorf("PHPDate with a French locale: got [%s], expected [1er mai 2025]", got)
+	}
+}
+
+func TestPHPDate_DaylightFlag(t *testing.T) {
+	tests := []struct {
+		zone     string
+		month    time.Month
+		expected string
+	}{
+		{"Europe/London", time.January, "0 GMT"},
+		{"Europe/London", time.July, "1 BST"},
+		// tzdata marks Irish winter time as negative daylight saving time
+		{"Europe/Dublin", time.January, "0 GMT"},
+		{"Europe/Dublin", time.July, "1 IST"},
+		{"UTC", time.July, "0 UTC"},
+	}
+	for _, tt := range tests {
+		loc, err := time.LoadLocation(tt.zone)
+		if err != nil {
+			t.Fatalf("LoadLocation(%q) returned error: %v", tt.zone, err)
+		}
+		if got := PHPDate("I T", time.Date(2025, tt.month, 15, 12, 0, 0, 0, loc), nil); got != tt.expected {
+			t.Errorf("PHPDate(\"I T\") in %s in %s: got [%s], expected [%s]", tt.zone, tt.month, got, tt.expected)
+		}
+	}
+}
+
+func TestFromPHPDate(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"Y-m-d H:i:s", "%Y-%m-%d %H:%M:%S"},
+		{"jS F Y", "%o %B %Y"},
+		{"dS M", "%*2d %b"},
+		{"D, d M Y", "%a, %d %b %Y"},
+		{"g:i a", "%-I:%M %#p"},
+		{`\Y\e\a\r: Y, 100%`, "Year: %Y, 100%%"},
+		{"c", "%Y-%m-%dT%H:%M:%S%:z"},
+		{"N U u v", "%u %s %f %L"},
+	}
+
+	times := []time.Time{
+		time.Date(2001, time.March, 1, 17, 16, 18, 0, time.FixedZone("MST", -7*3600)),
+		time.Date(2025, time.December, 22, 0, 5, 9, 123456789, time.UTC),
+	}
+	for _, tt := range tests {
+		format, err := FromPHPDate(tt.format)
+		if err != nil {
+			t.Errorf("FromPHPDate(%q) returned error: %v", tt.format, err)
+			continue
+		}
+		if format != tt.expected {
+			t.Errorf("FromPHPDate(%q): got [%s], expected [%s]", tt.format, format, tt.expected)
+		}
+		for _, tm := range times {
+			if got, want := Strftime(format, tm), PHPDate(tt.format, tm, nil); got != want {
+				t.Errorf("Strftime(%q) at %v: got [%s], expected [%s]", format, tm, got, want)
+			}
+		}
+	}
+
+	format, err := FromPHPDate("Y-m-d t L S")
+	if format != "%Y-%m-%d   " {
+		t.Errorf("FromPHPDate should translate the rest of the format, got [%s]", format)
+	}
+	var terr *TranslationError
+	if !errors.As(err, &terr) {
+		t.Fatalf("FromPHPDate expected a *TranslationError, got %v", err)
+	}
+	var texts []string
+	for _, u := range terr.Untranslated {
+		texts = append(texts, u.Text)
+	}
+	if expected := []string{"t", "L", "S"}; !reflect.DeepEqual(texts, expected) {
+		t.Errorf("Untranslated characters: got %q, expected %q", texts, expected)
+	}
+}
@@ -0,0 +1,115 @@
+package strftime
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// FormatFunc appends the value of a custom specifier for t to dst.
+// It receives the flags and width given in the format and is responsible for honoring them.
+type FormatFunc func(dst []byte, t time.Time, loc *Locale, mods Modifiers) []byte
+
+// ParseFunc reads the value of a custom specifier from the start of s into fields.
+// It returns the number of bytes of s it consumed.
+type ParseFunc func(s string, fields *Fields, loc *Locale, mods Modifiers) (int, error)
+
+// Fields holds the components of the time being parsed, as seen and updated by a ParseFunc
+type Fields struct {
+	Year       int
+	Month      int // 1-12
+	Day        int
+	Hour       int // 0-23
+	Minute     int
+	Second     int
+	Nanosecond int
+	Location   *time.Location // nil keeps the location passed to parsing
+}
+
+// Specifier is a custom conversion specifier
+type Specifier struct {
+	Format FormatFunc
+	Parse  ParseFunc // nil if the specifier cannot be parsed
+}
+
+// SpecifierSet maps runes to custom conversion specifiers.
+// It is safe for concurrent use by multiple goroutines.
+type SpecifierSet struct {
+	mu    sync.RWMutex
+	specs map[rune]Specifier
+}
+
+// DefaultSpecifiers is consulted by every formatting and parsing call,
+// after the set given with WithSpecifiers
+var DefaultSpecifiers = NewSpecifierSet()
+
+// NewSpecifierSet returns an empty SpecifierSet
+func NewSpecifierSet() *SpecifierSet {
+	return &SpecifierSet{specs: make(map[rune]Specifier)}
+}
+
+// Register binds r to a custom specifier, replacing any previous binding of r in the set.
+// parse may be nil, in which case parsing a format that uses r fails.
+// Built-in specifiers, flags and modifiers cannot be registered, apart from %i, %J, %K, %o and %q:
+// a binding of one of them takes precedence over the built-in.
+func (s *SpecifierSet) Register(r rune, format FormatFunc, parse ParseFunc) error {
+	if format == nil {
+		return fmt.Errorf("strftime: nil FormatFunc for specifier %%%c", r)
+	}
+	if !utf8.ValidRune(r) || strings.ContainsRune("%-_0123456789^#*:EO", r) {
+		return fmt.Errorf("strftime: %q cannot be used as a specifier", r)
+	}
+	if r < utf8.RuneSelf && isBuiltinSpecifier(byte(r)) {
+		return fmt.Errorf("strftime: %%%c is a built-in specifier", r)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.specs[r] = Specifier{Format: format, Parse: parse}
+	return nil
+}
+
+// Unregister removes the binding of r from the set
+func (s *SpecifierSet) Unregister(r rune) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.specs, r)
+}
+
+// lookup returns the specifier bound to r
+func (s *SpecifierSet) lookup(r rune) (Specifier, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	spec, ok := s.specs[r]
+	return spec, ok
+}
+
+// WithSpecifiers adds a set of custom specifiers, consulted before DefaultSpecifiers
+func WithSpecifiers(set *SpecifierSet) Option {
+	return func(o *options) {
+		o.specifiers = set
+	}
+}
+
+// specifier returns the custom specifier for the rune at the start of s and its encoded length
+func (o *options) specifier(s string) (Specifier, int, bool) {
+	r, size := utf8.DecodeRuneInString(s)
+	if o.specifiers != nil {
+		if spec, ok := o.specifiers.lookup(r); ok {
+			return spec, size, true
+		}
+	}
+	if set := DefaultSpecifiers; set != nil {
+		if spec, ok := set.lookup(r); ok {
+			return spec, size, true
+		}
+	}
+	return Specifier{}, 0, false
+}
+
+// isBuiltinSpecifier reports whether spec is a built-in conversion that registered specifiers can't override
+func isBuiltinSpecifier(spec byte) bool {
+	def := lookupSpec(spec)
+	return def != nil && !def.registrable
+}
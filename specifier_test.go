@@ -0,0 +1,172 @@
+package strftime
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sprintFormat writes the two-week sprint number of the year, honoring the padding flags and width
+func sprintFormat(dst []byte, t time.Time, _ *Locale, mods Modifiers) []byte {
+	sprint := int64((t.YearDay()-1)/14 + 1)
+	digits := 2
+	if mods.Width > 0 {
+		digits = mods.Width
+	}
+	if mods.Pad == '-' {
+		digits = 0
+	}
+	return appendInt(dst, sprint, digits, '0')
+}
+
+// buildFormat writes the build epoch in hours, parsing it back into the time
+func buildFormat(dst []byte, t time.Time, _ *Locale, _ Modifiers) []byte {
+	return strconv.AppendInt(dst, t.Unix()/3600, 10)
+}
+
+func buildParse(s string, fields *Fields, _ *Locale, _ Modifiers) (int, error) {
+	n := 0
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	hours, err := strconv.ParseInt(s[:n], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	t := time.Unix(hours*3600, 0).UTC()
+	fields.Year, fields.Month, fields.Day = t.Year(), int(t.Month()), t.Day()
+	fields.Hour, fields.Minute, fields.Second, fields.Nanosecond = t.Hour(), 0, 0, 0
+	fields.Location = time.UTC
+	return n, nil
+}
+
+func TestSpecifierSet(t *testing.T) {
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+
+	set := NewSpecifierSet()
+	if err := set.Register('@', sprintFormat, nil); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if err := set.Register('★', func(dst []byte, _ time.Time, _ *Locale, mods Modifiers) []byte {
+		return strconv.AppendInt(append(dst, "mods:"...), int64(mods.Colons), 10)
+	}, nil); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"Sprint %@", "Sprint 03"},
+		{"Sprint %-@", "Sprint 3"},
+		{"Sprint %4@", "Sprint 0003"},
+		{"%Y-S%@", "2025-S03"},
+		{"%★ %::★", "mods:0 mods:2"},
+	}
+	for _, tt := range tests {
+		formatted := StrftimeWith(tt.format, testTime, WithSpecifiers(set))
+		if formatted != tt.expected {
+			t.Errorf("StrftimeWith(%q): got [%s], expected [%s]", tt.format, formatted, tt.expected)
+		}
+		f, err := Compile(tt.format, WithSpecifiers(set))
+		if err != nil {
+			t.Fatalf("Compile(%q) returned error: %v", tt.format, err)
+		}
+		if formatted := f.Format(testTime); formatted != tt.expected {
+			t.Errorf("Compiled %q: got [%s], expected [%s]", tt.format, formatted, tt.expected)
+		}
+	}
+
+	// Sets don't leak into calls that don't use them
+	if formatted := StrftimeWith("%@", testTime); formatted != "@" {
+		t.Errorf("StrftimeWith without set: got [%s], expected [@]", formatted)
+	}
+	if _, err := StrftimeE("%@", testTime, nil); err == nil {
+		t.Error("StrftimeE without set expected error, but got none")
+	}
+	if formatted, err := StrftimeE("%@", testTime, nil, WithSpecifiers(set)); err != nil || formatted != "03" {
+		t.Errorf("StrftimeE with set: got [%s], %v", formatted, err)
+	}
+
+	set.Unregister('@')
+	if formatted := StrftimeWith("%@", testTime, WithSpecifiers(set)); formatted != "@" {
+		t.Errorf("StrftimeWith after Unregister: got [%s], expected [@]", formatted)
+	}
+}
+
+func TestDefaultSpecifiers(t *testing.T) {
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+
+	if err := DefaultSpecifiers.Register('@', sprintFormat, nil); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	defer DefaultSpecifiers.Unregister('@')
+
+	if formatted := StrftimeL("S%@", testTime, nil); formatted != "S03" {
+		t.Errorf("StrftimeL with default set: got [%s], expected [S03]", formatted)
+	}
+
+	// A per-call set takes precedence over the default set
+	set := NewSpecifierSet()
+	_ = set.Register('@', func(dst []byte, _ time.Time, _ *Locale, _ Modifiers) []byte {
+		return append(dst, "local"...)
+	}, nil)
+	if formatted := StrftimeWith("S%@", testTime, WithSpecifiers(set)); formatted != "Slocal" {
+		t.Errorf("StrftimeWith with per-call set: got [%s], expected [Slocal]", formatted)
+	}
+}
+
+func TestRegisterErrors(t *testing.T) {
+	set := NewSpecifierSet()
+	for _, r := range []rune{'Y', 'c', 'z', '%', '-', '5', ':', 'E', 'O'} {
+		if err := set.Register(r, sprintFormat, nil); err == nil {
+			t.Errorf("Register(%q) expected error, but got none", r)
+		}
+	}
+	if err := set.Register('@', nil, nil); err == nil {
+		t.Error("Register with nil FormatFunc expected error, but got none")
+	}
+}
+
+func TestParseCustomSpecifier(t *testing.T) {
+	set := NewSpecifierSet()
+	if err := set.Register('@', buildFormat, buildParse); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	if err := set.Register('Q', sprintFormat, nil); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+
+	testTime := time.Date(2025, time.February, 3, 9, 0, 0, 0, time.UTC)
+	formatted := StrftimeWith("build %@ at %M:%S", testTime.Add(5*time.Minute+7*time.Second), WithSpecifiers(set))
+	parsed, err := ParseWith("build %@ at %M:%S", formatted, WithSpecifiers(set))
+	if err != nil {
+		t.Fatalf("ParseWith(%q) returned error: %v", formatted, err)
+	}
+	expected := testTime.Add(5*time.Minute + 7*time.Second)
+	if !parsed.Equal(expected) {
+		t.Errorf("ParseWith(%q): got [%v], expected [%v]", formatted, parsed, expected)
+	}
+
+	if _, err := ParseWith("%Q", "03", WithSpecifiers(set)); err == nil || !strings.Contains(err.Error(), "%Q") {
+		t.Errorf("ParseWith with a specifier without parser: got %v", err)
+	}
+	if _, err := ParseWith("%@", "1"); err == nil {
+		t.Error("ParseWith without set expected error, but got none")
+	}
+}
+
+func TestCustomSpecifierAllocs(t *testing.T) {
+	set := NewSpecifierSet()
+	_ = set.Register('@', sprintFormat, nil)
+	f := MustCompile("%Y-S%@", WithSpecifiers(set))
+	testTime := time.Date(2025, time.February, 3, 9, 5, 7, 0, time.UTC)
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = f.AppendFormat(buf[:0], testTime)
+	})
+	if allocs != 0 {
+		t.Errorf("AppendFormat with custom specifier: got %v allocations, expected 0", allocs)
+	}
+}
@@ -0,0 +1,664 @@
+package strftime
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SpecInfo describes a built-in specifier
+type SpecInfo struct {
+	Specifier   string // Specifier as written, with its E modifier or colons, such as "%d" or "%:z"
+	Name        string // Short name, such as "day" or "offset-colon"
+	Description string // English description, as in EnglishDescriptions
+	Format      bool   // Whether StrftimeL writes it
+	Parse       bool   // Whether ParseL reads it
+	Min, Max    int    // Range of numeric values, both 0 for text and for numbers without bounds
+	Width       int    // Digits written when no width or padding flag is given, 0 for text and unpadded numbers
+	Locale      bool   // Whether the output depends on the Locale
+}
+
+// Specifiers returns the built-in specifiers, including the E and colon forms with a meaning of their own,
+// from the table StrftimeL and ParseL dispatch from. Flags, widths and the O modifier apply to them as documented.
+func Specifiers() []SpecInfo {
+	var infos []SpecInfo
+	for k := range specDefs {
+		def := &specDefs[k]
+		infos = append(infos, def.info(specForm{name: def.name, min: def.min, max: def.max, width: def.width, locale: def.locale}))
+		for _, form := range def.forms {
+			infos = append(infos, def.info(form))
+		}
+	}
+	return infos
+}
+
+// info describes the form of the conversion
+func (def *specDef) info(form specForm) SpecInfo {
+	key := strings.Repeat(":", form.colons)
+	if form.alt != 0 {
+		key += string(form.alt)
+	}
+	key += string(def.spec)
+	return SpecInfo{
+		Specifier:   "%" + key,
+		Name:        form.name,
+		Description: EnglishDescriptions[key],
+		Format:      def.formats(form.colons, form.alt),
+		Parse:       def.parses(form.colons, form.alt),
+		Min:         form.min,
+		Max:         form.max,
+		Width:       form.width,
+		Locale:      form.locale,
+	}
+}
+
+// formats reports whether StrftimeL writes the conversion with the given colons and modifier
+func (def *specDef) formats(colons int, alt byte) bool {
+	var o op
+	opts := newOptions(nil)
+	directiveOp(&o, string(rune(def.spec)), &Modifiers{Colons: colons, Alt: alt}, &opts)
+	return o.kind != opUnknown
+}
+
+// parses reports whether ParseL reads the conversion with the given colons and modifier
+func (def *specDef) parses(colons int, alt byte) bool {
+	if colons > def.parseColons {
+		return false
+	}
+	if alt == 'E' && def.parseEra != nil {
+		return true
+	}
+	return def.parse != nil
+}
+
+// specDef defines a built-in conversion character for both formatting and parsing
+type specDef struct {
+	spec        byte
+	name        string
+	min, max    int  // Range of numeric values, also checked by the parser
+	width       int  // Default number of digits
+	locale      bool // Whether the output depends on the locale
+	colons      int  // Most colons the formatter accepts, as in %:::z
+	parseColons int  // Most colons the parser accepts
+	registrable bool // Whether a registered specifier of the same letter takes precedence
+
+	// How the formatter builds the op, see directiveOp
+	format   specFormat
+	field    field     // Field of numbers and years
+	pad      byte      // Padding of numbers, '0' if unset
+	names    nameTable // Names written by formatName
+	text     string    // Text written by formatLiteral
+	ordinal  bool      // Whether numbers have the locale's ordinal suffix, as in %o
+	eraWidth int       // Digits of the E form outside any era, 0 if the E modifier is ignored
+
+	parse    func(p *fieldParser) error // nil if ParseL can't read the conversion
+	parseEra func(p *fieldParser) error // Reads the E form when the locale has eras, nil if it reads as the plain form
+
+	forms []specForm // E and colon forms with a meaning of their own
+}
+
+// specFormat selects how the formatter builds the op of a conversion
+type specFormat uint8
+
+const (
+	formatNumber       specFormat = iota // Number of the field, padded to the width with pad
+	formatYear                           // Full year of the field, expanded by WithExpandedYears
+	formatFraction                       // Fractional seconds with width digits
+	formatName                           // Locale's name from names
+	formatLiteral                        // Fixed text
+	formatComposite                      // Locale's pattern for the conversion
+	formatEraComposite                   // Locale's pattern, or its alternative representation with E
+	formatZone                           // Zone abbreviation, or localized zone name with colons
+	formatOffset                         // UTC offset, "Z" for UTC with E
+)
+
+// specForm describes a form of a conversion selected by colons or an E modifier
+type specForm struct {
+	colons   int
+	alt      byte
+	name     string
+	min, max int
+	width    int
+	locale   bool
+}
+
+// specDefs holds the built-in conversions in the order Specifiers lists them, and specIndex indexes them
+// by conversion character. Both are filled in by init, as parsing composites refers back to them through parseInto.
+var (
+	specDefs  []specDef
+	specIndex [utf8.RuneSelf]*specDef
+)
+
+// lookupSpec returns the built-in conversion spec, nil if there is none
+func lookupSpec(spec byte) *specDef {
+	if spec >= utf8.RuneSelf {
+		return nil
+	}
+	return specIndex[spec]
+}
+
+func init() {
+	specDefs = []specDef{
+		{
+			spec: 'A', name: "weekday-name", locale: true,
+			format: formatName, names: nameWeekdayFull,
+			parse: parseWeekdayName,
+		},
+		{
+			spec: 'a', name: "weekday-abbrev", locale: true,
+			format: formatName, names: nameWeekdayAbbrev,
+			parse: parseWeekdayName,
+		},
+		{
+			spec: 'B', name: "month-name", locale: true,
+			format: formatName, names: nameMonthFull,
+			parse: parseMonthName,
+		},
+		{
+			spec: 'b', name: "month-abbrev", locale: true,
+			format: formatName, names: nameMonthAbbrev,
+			parse: parseMonthName,
+		},
+		{
+			spec: 'h', name: "month-abbrev-h", locale: true,
+			format: formatName, names: nameMonthAbbrev,
+			parse: parseMonthName,
+		},
+		{
+			spec: 'C', name: "century", min: 0, max: 99, width: 2,
+			format: formatNumber, field: fieldCentury, eraWidth: 2,
+			parseEra: func(p *fieldParser) error {
+				var err error
+				p.result.era, p.j, err = parseEraName(p.s, p.j, p.o.locale.Eras)
+				return err
+			},
+			forms: []specForm{{alt: 'E', name: "era-name", locale: true}},
+		},
+		{
+			spec: 'c', name: "date-time", locale: true,
+			format: formatEraComposite,
+			parse:  parseEraComposite,
+			forms:  []specForm{{alt: 'E', name: "era-date-time", locale: true}},
+		},
+		{
+			spec: 'D', name: "date-mdy",
+			format: formatComposite,
+			parse: func(p *fieldParser) error {
+				// "%D" equals "%m/%d/%y"
+				result := p.result
+				result.month, p.j, _ = parseFixedInt(p.s, p.j, 2)
+				result.monthSet = true
+				if p.j >= len(p.s) || p.s[p.j] != '/' {
+					return fmt.Errorf("expected '/' after month in %%D")
+				}
+				p.j++
+				result.day, p.j, _ = parseFixedInt(p.s, p.j, 2)
+				result.daySet = true
+				if p.j >= len(p.s) || p.s[p.j] != '/' {
+					return fmt.Errorf("expected '/' after day in %%D")
+				}
+				p.j++
+				var twoDigit int
+				twoDigit, p.j, _ = parseFixedInt(p.s, p.j, 2)
+				result.year = twoDigitYear(twoDigit)
+				return nil
+			},
+		},
+		{
+			spec: 'd', name: "day", min: 1, max: 31, width: 2,
+			format: formatNumber, field: fieldDay,
+			parse: func(p *fieldParser) error {
+				p.result.day, _ = p.number(2)
+				p.result.daySet = true
+				return nil
+			},
+		},
+		{
+			spec: 'e', name: "day-space", min: 1, max: 31, width: 2,
+			format: formatNumber, field: fieldDay, pad: ' ',
+			parse: func(p *fieldParser) error {
+				// One or two digits, after a leading space if there is one
+				if p.j < len(p.s) && p.s[p.j] == ' ' {
+					p.j++
+				}
+				if alt := p.o.locale.AltDigits; p.alt == 'O' && len(alt) > 0 {
+					p.result.day, p.j, _ = parseAltDigits(p.s, p.j, 2, '-', alt)
+				} else {
+					p.result.day, p.j, _ = parseIntVariable(p.s, p.j, 1, 2)
+				}
+				p.result.daySet = true
+				return nil
+			},
+		},
+		{
+			spec: 'F', name: "date-iso",
+			format: formatComposite,
+			parse: func(p *fieldParser) error {
+				// Equivalent to "%Y-%m-%d"
+				result := p.result
+				var err error
+				result.year, p.j, err = parseYear(p.s, p.j, 4, 0, p.o.yearDigits)
+				if err != nil {
+					return err
+				}
+				if p.j >= len(p.s) || p.s[p.j] != '-' {
+					return fmt.Errorf("expected '-' after year in %%F")
+				}
+				p.j++
+				result.month, p.j, _ = parseFixedInt(p.s, p.j, 2)
+				result.monthSet = true
+				if p.j >= len(p.s) || p.s[p.j] != '-' {
+					return fmt.Errorf("expected '-' after month in %%F")
+				}
+				p.j++
+				result.day, p.j, _ = parseFixedInt(p.s, p.j, 2)
+				result.daySet = true
+				return nil
+			},
+		},
+		{
+			spec: 'f', name: "microsecond", min: 0, max: 999999, width: 6,
+			format: formatFraction,
+			parse:  func(p *fieldParser) error { return p.fraction(6) },
+		},
+		{
+			spec: 'G', name: "iso-year", width: 4,
+			format: formatYear, field: fieldISOYear,
+			parse: func(p *fieldParser) error {
+				var err error
+				if p.result.isoYear, err = p.year(); err != nil {
+					return err
+				}
+				p.result.isoYearSet = true
+				return nil
+			},
+		},
+		{
+			spec: 'g', name: "iso-year-short", min: 0, max: 99, width: 2,
+			format: formatNumber, field: fieldISOYear2,
+			parse: func(p *fieldParser) error {
+				// Converted like %y
+				twoDigit, err := p.number(2)
+				if err != nil {
+					return err
+				}
+				p.result.isoYear, p.result.isoYearSet = twoDigitYear(twoDigit), true
+				return nil
+			},
+		},
+		{
+			spec: 'H', name: "hour", min: 0, max: 23, width: 2,
+			format: formatNumber, field: fieldHour,
+			parse: func(p *fieldParser) error {
+				p.result.hour, _ = p.number(2)
+				return nil
+			},
+		},
+		{
+			spec: 'I', name: "hour12", min: 1, max: 12, width: 2,
+			format: formatNumber, field: fieldHour12,
+			parse: func(p *fieldParser) error {
+				p.result.hour, _ = p.number(2)
+				p.result.hour12 = true
+				return nil
+			},
+		},
+		{
+			spec: 'i', registrable: true, name: "quarter-day", min: 1, max: 92, width: 2,
+			format: formatNumber, field: fieldQuarterDay,
+			parse: func(p *fieldParser) error {
+				var err error
+				p.result.quarterDay, err = p.bounded(2)
+				p.result.quarterDaySet = err == nil
+				return err
+			},
+		},
+		{
+			spec: 'J', registrable: true, name: "half", min: 1, max: 2, width: 1,
+			format: formatNumber, field: fieldHalf,
+			parse: func(p *fieldParser) error {
+				var err error
+				p.result.half, err = p.bounded(1)
+				p.result.halfSet = err == nil
+				return err
+			},
+		},
+		{
+			spec: 'j', name: "year-day", min: 1, max: 366, width: 3,
+			format: formatNumber, field: fieldYearDay,
+			parse: func(p *fieldParser) error {
+				// Resolved against the year once parsing is done
+				yday, err := p.number(3)
+				if err != nil {
+					return err
+				}
+				if yday < p.def.min || yday > p.def.max {
+					return fmt.Errorf("invalid day of year %d for %%j", yday)
+				}
+				p.result.month, p.result.monthSet = 1, true
+				p.result.day, p.result.daySet = yday, true
+				p.result.yearDay, p.result.yearDaySet = yday, true
+				return nil
+			},
+		},
+		{
+			spec: 'K', registrable: true, name: "month-week", min: 1, max: 6, width: 1, locale: true,
+			format: formatNumber, field: fieldWeekOfMonth,
+			parse: func(p *fieldParser) error {
+				var err error
+				p.result.weekOfMonth, err = p.bounded(1)
+				p.result.weekOfMonthSet = err == nil
+				return err
+			},
+		},
+		{
+			spec: 'k', name: "hour-space", min: 0, max: 23, width: 2,
+			format: formatNumber, field: fieldHour, pad: ' ',
+		},
+		{
+			spec: 'L', name: "millisecond", min: 0, max: 999, width: 3,
+			format: formatFraction,
+			parse:  func(p *fieldParser) error { return p.fraction(3) },
+		},
+		{
+			spec: 'l', name: "hour12-space", min: 1, max: 12, width: 2,
+			format: formatNumber, field: fieldHour12, pad: ' ',
+		},
+		{
+			spec: 'M', name: "minute", min: 0, max: 59, width: 2,
+			format: formatNumber, field: fieldMinute,
+			parse: func(p *fieldParser) error {
+				p.result.minute, _ = p.number(2)
+				return nil
+			},
+		},
+		{
+			spec: 'm', name: "month", min: 1, max: 12, width: 2,
+			format: formatNumber, field: fieldMonth,
+			parse: func(p *fieldParser) error {
+				p.result.month, _ = p.number(2)
+				p.result.monthSet = true
+				return nil
+			},
+		},
+		{
+			spec: 'N', name: "nanosecond", min: 0, max: 999999999, width: 9,
+			format: formatFraction,
+			parse:  func(p *fieldParser) error { return p.fraction(9) },
+		},
+		{
+			spec: 'n', name: "newline",
+			format: formatLiteral, text: "\n",
+		},
+		{
+			spec: 'o', registrable: true, name: "day-ordinal", min: 1, max: 31, locale: true,
+			format: formatNumber, field: fieldDay, ordinal: true,
+			parse: func(p *fieldParser) error {
+				p.ordinal = true
+				var err error
+				p.result.day, err = p.number(2)
+				p.result.daySet = true
+				return err
+			},
+		},
+		{
+			spec: 'p', name: "am-pm", locale: true,
+			format: formatName, names: nameAMPM,
+			parse: func(p *fieldParser) error {
+				locale := p.o.locale
+				switch {
+				case hasPrefix(p.s[p.j:], locale.AM, p.fold):
+					p.result.ampmSet, p.result.isPM = true, false
+					p.j += len(locale.AM)
+				case hasPrefix(p.s[p.j:], locale.PM, p.fold):
+					p.result.ampmSet, p.result.isPM = true, true
+					p.j += len(locale.PM)
+				default:
+					return fmt.Errorf("expected AM/PM marker at position %d", p.j)
+				}
+				return nil
+			},
+		},
+		{
+			spec: 'q', registrable: true, name: "quarter", min: 1, max: 4, width: 1,
+			format: formatNumber, field: fieldQuarter,
+			parse: func(p *fieldParser) error {
+				var err error
+				p.result.quarter, err = p.bounded(1)
+				p.result.quarterSet = err == nil
+				return err
+			},
+		},
+		{spec: 'R', name: "time-hm", format: formatComposite, parse: parseComposite},
+		{spec: 'r', name: "time-12", locale: true, format: formatComposite, parse: parseComposite},
+		{
+			spec: 'S', name: "second", min: 0, max: 60, width: 2,
+			format: formatNumber, field: fieldSecond,
+			parse: func(p *fieldParser) error {
+				p.result.second, _ = p.number(2)
+				return nil
+			},
+		},
+		{
+			spec: 's', name: "unix", width: 1,
+			format: formatNumber, field: fieldUnix,
+		},
+		{spec: 'T', name: "time-hms", format: formatComposite, parse: parseComposite},
+		{
+			spec: 't', name: "tab",
+			format: formatLiteral, text: "\t",
+		},
+		{
+			spec: 'U', name: "week-sunday", min: 0, max: 53, width: 2,
+			format: formatNumber, field: fieldWeekSunday,
+			parse: parseWeek,
+		},
+		{
+			spec: 'u', name: "weekday-iso", min: 1, max: 7, width: 1,
+			format: formatNumber, field: fieldWeekdayISO,
+			parse: parseWeekday,
+		},
+		{
+			spec: 'V', name: "iso-week", min: 1, max: 53, width: 2,
+			format: formatNumber, field: fieldISOWeek,
+			parse: func(p *fieldParser) error {
+				// Resolved against the week-based year once parsing is done
+				var err error
+				if p.result.isoWeek, err = p.number(2); err != nil {
+					return err
+				}
+				if p.result.isoWeek < p.def.min || p.result.isoWeek > p.def.max {
+					return fmt.Errorf("invalid week number %d for %%V", p.result.isoWeek)
+				}
+				p.result.isoWeekSet = true
+				return nil
+			},
+		},
+		{spec: 'v', name: "date-dmy", locale: true, format: formatComposite},
+		{
+			spec: 'W', name: "week-monday", min: 0, max: 53, width: 2,
+			format: formatNumber, field: fieldWeekMonday,
+			parse: parseWeek,
+		},
+		{
+			spec: 'w', name: "weekday", min: 0, max: 6, width: 1,
+			format: formatNumber, field: fieldWeekday,
+			parse: parseWeekday,
+		},
+		{
+			spec: 'X', name: "time", locale: true,
+			format: formatEraComposite,
+			parse:  parseEraComposite,
+			forms:  []specForm{{alt: 'E', name: "era-time", locale: true}},
+		},
+		{
+			spec: 'x', name: "date", locale: true,
+			format: formatEraComposite,
+			parse:  parseEraComposite,
+			forms:  []specForm{{alt: 'E', name: "era-date", locale: true}},
+		},
+		{
+			spec: 'Y', name: "year", width: 4,
+			format: formatYear, field: fieldYear, eraWidth: 4,
+			parse: func(p *fieldParser) error {
+				var err error
+				p.result.year, err = p.year()
+				return err
+			},
+			parseEra: func(p *fieldParser) error {
+				// The first era whose pattern matches wins
+				var err error
+				p.j, err = parseEraYear(p.result, p.s, p.j, p.o)
+				return err
+			},
+			forms: []specForm{{alt: 'E', name: "era-full-year", locale: true}},
+		},
+		{
+			spec: 'y', name: "year-short", min: 0, max: 99, width: 2,
+			format: formatNumber, field: fieldYear2, eraWidth: 1,
+			parse: func(p *fieldParser) error {
+				twoDigit, _ := p.number(2)
+				p.result.year = twoDigitYear(twoDigit)
+				return nil
+			},
+			parseEra: func(p *fieldParser) error {
+				var err error
+				p.result.eraYear, p.j, err = parseIntVariable(p.s, p.j, 1, 4)
+				p.result.eraYearSet = true
+				return err
+			},
+			forms: []specForm{{alt: 'E', name: "era-year", width: 1, locale: true}},
+		},
+		{
+			// Time zone abbreviation, or with colons the locale's %:Z short, %::Z long and %:::Z generic zone name
+			spec: 'Z', name: "zone", colons: 3,
+			format: formatZone,
+			parse: func(p *fieldParser) error {
+				// UTC and GMT select UTC and other names keep the default location
+				start := p.j
+				for p.j < len(p.s) && (p.s[p.j] >= 'A' && p.s[p.j] <= 'Z' || p.s[p.j] >= 'a' && p.s[p.j] <= 'z') {
+					p.j++
+				}
+				if p.j == start {
+					return fmt.Errorf("expected time zone name at position %d", p.j)
+				}
+				if name := p.s[start:p.j]; name == "UTC" || name == "GMT" {
+					p.result.loc = time.UTC
+				}
+				return nil
+			},
+			forms: []specForm{
+				{colons: 1, name: "zone-short", locale: true},
+				{colons: 2, name: "zone-long", locale: true},
+				{colons: 3, name: "zone-generic", locale: true},
+			},
+		},
+		{
+			// +hhmm, %:z +hh:mm, %::z +hh:mm:ss, %:::z with as many colons as necessary; %Ez writes "Z" for UTC
+			spec: 'z', name: "offset", colons: 3, parseColons: 3,
+			format: formatOffset,
+			parse: func(p *fieldParser) error {
+				// Any of the forms of %z, %:z, %::z and %:::z, or "Z"
+				var err error
+				p.result.loc, p.j, err = parseOffset(p.s, p.j)
+				return err
+			},
+			forms: []specForm{
+				{colons: 1, name: "offset-colon"},
+				{colons: 2, name: "offset-seconds"},
+				{colons: 3, name: "offset-minimal"},
+				{alt: 'E', name: "offset-zulu"},
+				{colons: 1, alt: 'E', name: "offset-zulu-colon"},
+				{colons: 2, alt: 'E', name: "offset-zulu-seconds"},
+				{colons: 3, alt: 'E', name: "offset-zulu-minimal"},
+			},
+		},
+		{spec: '+', name: "date-cmd", locale: true, format: formatComposite, parse: parseComposite},
+		{
+			spec: '%', name: "percent",
+			format: formatLiteral, text: "%",
+			parse: func(p *fieldParser) error {
+				if p.j >= len(p.s) || p.s[p.j] != '%' {
+					return fmt.Errorf("expected literal '%%' at position %d", p.j)
+				}
+				p.j++
+				return nil
+			},
+		},
+	}
+	for k := range specDefs {
+		specIndex[specDefs[k].spec] = &specDefs[k]
+	}
+}
+
+// parseComposite reads a composite specifier through its expansion
+func parseComposite(p *fieldParser) error {
+	return p.composite(p.o.composite(p.spec), "%"+string(p.spec))
+}
+
+// parseEraComposite reads %c, %x and %X, or %Ec, %Ex and %EX through the locale's alternative representations
+func parseEraComposite(p *fieldParser) error {
+	if p.alt == 'E' {
+		return p.composite(p.o.locale.eraComposite(p.spec), "%E"+string(p.spec))
+	}
+	return parseComposite(p)
+}
+
+// parseMonthName reads the full or abbreviated month name of %B, %b and %h
+func parseMonthName(p *fieldParser) error {
+	names, what := p.o.locale.MonthsAbbrev, "abbreviated month name"
+	if p.spec == 'B' {
+		names, what = p.o.locale.MonthsFull, "full month name"
+	}
+	month, err := p.name(names, what)
+	if err != nil {
+		return err
+	}
+	p.result.month, p.result.monthSet = month+1, true
+	return nil
+}
+
+// parseWeekdayName reads the full or abbreviated weekday name of %A and %a, only used to resolve week numbers
+func parseWeekdayName(p *fieldParser) error {
+	names, what := p.o.locale.WeekdaysAbbrev, "abbreviated weekday name"
+	if p.spec == 'A' {
+		names, what = p.o.locale.WeekdaysFull, "full weekday name"
+	}
+	weekday, err := p.name(names, what)
+	if err != nil {
+		return err
+	}
+	p.result.weekday, p.result.weekdaySet = time.Weekday(weekday), true
+	return nil
+}
+
+// parseWeekday reads the weekday number of %u, where Monday is 1, and %w, where Sunday is 0
+func parseWeekday(p *fieldParser) error {
+	wd, err := p.number(1)
+	if err != nil {
+		return err
+	}
+	if wd < p.def.min || wd > p.def.max {
+		return fmt.Errorf("invalid weekday %d for %%%c", wd, p.spec)
+	}
+	p.result.weekday, p.result.weekdaySet = time.Weekday(wd%7), true
+	return nil
+}
+
+// parseWeek reads the week of year of %U and %W, with weeks starting on Sunday and Monday
+func parseWeek(p *fieldParser) error {
+	var err error
+	if p.result.week, err = p.number(2); err != nil {
+		return err
+	}
+	if p.result.week > p.def.max {
+		return fmt.Errorf("invalid week number %d for %%%c", p.result.week, p.spec)
+	}
+	p.result.weekStart = time.Sunday
+	if p.spec == 'W' {
+		p.result.weekStart = time.Monday
+	}
+	p.result.weekSet = true
+	return nil
+}
@@ -0,0 +1,94 @@
+package strftime
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSpecifiers(t *testing.T) {
+	infos := Specifiers()
+	seen := make(map[string]bool)
+	names := make(map[string]string)
+	for _, info := range infos {
+		if seen[info.Specifier] {
+			t.Errorf("Specifiers: %s listed twice", info.Specifier)
+		}
+		seen[info.Specifier] = true
+		if other, ok := names[info.Name]; ok {
+			t.Errorf("Specifiers: %s and %s are both named [%s]", other, info.Specifier, info.Name)
+		}
+		names[info.Name] = info.Specifier
+		if info.Name == "" || info.Description == "" {
+			t.Errorf("Specifiers: %s has name [%s] and description [%s]", info.Specifier, info.Name, info.Description)
+		}
+		if _, err := StrftimeE(info.Specifier, validationTimes[0], nil); (err == nil) != info.Format {
+			t.Errorf("Specifiers: %s has Format %v, but StrftimeE returned error: %v", info.Specifier, info.Format, err)
+		}
+	}
+	for _, spec := range []string{"%d", "%h", "%EY", "%:::z", "%:Ez", "%::Z", "%+", "%%"} {
+		if !seen[spec] {
+			t.Errorf("Specifiers: %s is missing", spec)
+		}
+	}
+}
+
+func TestSpecifiers_Metadata(t *testing.T) {
+	expected := map[string]SpecInfo{
+		"%d": {Specifier: "%d", Name: "day", Description: "day of month, zero-padded (01–31)",
+			Format: true, Parse: true, Min: 1, Max: 31, Width: 2},
+		"%B": {Specifier: "%B", Name: "month-name", Description: "full month name",
+			Format: true, Parse: true, Locale: true},
+		"%k": {Specifier: "%k", Name: "hour-space", Description: "hour, space-padded (0–23)",
+			Format: true, Min: 0, Max: 23, Width: 2},
+		"%Ey": {Specifier: "%Ey", Name: "era-year", Description: "year of the era",
+			Format: true, Parse: true, Width: 1, Locale: true},
+		"%::Z": {Specifier: "%::Z", Name: "zone-long", Description: "long localized time zone name",
+			Format: true, Locale: true},
+		"%::z": {Specifier: "%::z", Name: "offset-seconds", Description: "UTC offset (+hh:mm:ss)",
+			Format: true, Parse: true},
+	}
+	for _, info := range Specifiers() {
+		if want, ok := expected[info.Specifier]; ok && info != want {
+			t.Errorf("Specifiers: got [%+v], expected [%+v]", info, want)
+		}
+	}
+}
+
+func TestSpecifiers_Parse(t *testing.T) {
+	// Eras let the E forms be read back
+	locale := *DefaultLocale
+	locale.Eras = []Era{{Start: time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC), Name: "AD", Offset: 1}}
+	opts := newOptions([]Option{WithLocale(&locale)})
+
+	for _, info := range Specifiers() {
+		if got := parseable(info.Specifier, &opts); got != info.Parse {
+			t.Errorf("Specifiers: %s has Parse %v, but ParseL reading it back is %v", info.Specifier, info.Parse, got)
+		}
+	}
+}
+
+func TestSpecifiers_Range(t *testing.T) {
+	for _, info := range Specifiers() {
+		if info.Max == 0 {
+			continue
+		}
+		for _, tm := range validationTimes {
+			out := Strftime(info.Specifier, tm)
+			// Without the padding, and the ordinal suffix of %o
+			digits := strings.TrimRight(strings.TrimLeft(out, " "), "stndrh")
+			value, err := strconv.Atoi(digits)
+			if err != nil {
+				t.Errorf("Strftime(%q): got [%s], expected a number", info.Specifier, out)
+				continue
+			}
+			if value < info.Min || value > info.Max {
+				t.Errorf("Strftime(%q): got [%d], expected %d-%d", info.Specifier, value, info.Min, info.Max)
+			}
+			if info.Width > 0 && len(out) != info.Width {
+				t.Errorf("Strftime(%q): got [%s], expected %d characters", info.Specifier, out, info.Width)
+			}
+		}
+	}
+}
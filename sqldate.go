@@ -0,0 +1,435 @@
+package strftime
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// sqlElement is an element of a SQL date template together with its strftime equivalent
+type sqlElement struct {
+	offset  int    // Byte offset of the element in the template
+	text    string // Element as written in the template
+	literal string // Literal text the element stands for, "" for fields
+	spec    string // Equivalent strftime format, "" if there is none
+	reason  string // Why the element has no equivalent
+	lower   bool   // Lowercase the output, which strftime can't do for names
+	pad     int    // Pad the output on the right with blanks to this many characters
+}
+
+// sqlBaseTime supplies the fields that SQL templates don't parse, as the zero time.Time does
+var sqlBaseTime = time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// translateSQL joins the strftime equivalents of elems, listing the elements without one
+func translateSQL(source string, elems []sqlElement) (string, error) {
+	t := translation{source: source}
+	for _, e := range elems {
+		switch {
+		case e.reason != "":
+			t.skip(e.offset, e.offset+len(e.text), e.reason)
+		case e.lower:
+			t.skip(e.offset, e.offset+len(e.text), "lowercase names have no strftime equivalent")
+		case e.pad > 0:
+			t.skip(e.offset, e.offset+len(e.text), "names padded with blanks have no strftime equivalent, FM turns the padding off")
+		default:
+			t.out.WriteString(e.spec)
+		}
+	}
+	return t.result()
+}
+
+// untranslatedSQL returns a *TranslationError listing the elements without a strftime equivalent, or nil
+func untranslatedSQL(source string, elems []sqlElement) error {
+	t := translation{source: source}
+	for _, e := range elems {
+		if e.reason != "" {
+			t.skip(e.offset, e.offset+len(e.text), e.reason)
+		}
+	}
+	if t.err != nil {
+		return t.err
+	}
+	return nil
+}
+
+// formatSQL formats t with the strftime equivalents of elems, applying the case and padding strftime can't
+func formatSQL(source string, elems []sqlElement, t time.Time, loc *Locale) (string, error) {
+	if err := untranslatedSQL(source, elems); err != nil {
+		return "", err
+	}
+	if loc == nil {
+		loc = DefaultLocale
+	}
+	opts := options{locale: loc}
+	var dst []byte
+	for _, e := range elems {
+		start := len(dst)
+		dst = appendFormat(dst, e.spec, t, &opts)
+		if e.lower {
+			dst = append(dst[:start], bytes.ToLower(dst[start:])...)
+		}
+		for n := utf8.RuneCount(dst[start:]); n < e.pad; n++ {
+			dst = append(dst, ' ')
+		}
+	}
+	return string(dst), nil
+}
+
+// parseSQL parses s with the strftime equivalents of elems. Names match in any case and numbers may have
+// fewer digits, as CPython's strptime allows. Blanks are skipped before each field and after padded names,
+// and blanks in the template match any number of blanks, as both PostgreSQL and MySQL do.
+func parseSQL(source string, elems []sqlElement, s string, loc *Locale) (time.Time, error) {
+	if err := untranslatedSQL(source, elems); err != nil {
+		return time.Time{}, err
+	}
+	if loc == nil {
+		loc = DefaultLocale
+	}
+	o := options{locale: loc, dialect: DialectPython}
+	base := sqlBaseTime
+	result := parseResult{year: base.Year(), month: int(base.Month()), day: base.Day()}
+
+	skipBlanks := func(j int) int {
+		for j < len(s) && isSpace(s[j]) {
+			j++
+		}
+		return j
+	}
+
+	j := 0
+	for _, e := range elems {
+		if e.literal == "" {
+			var err error
+			if j, err = parseInto(&result, e.spec, s, skipBlanks(j), &o); err != nil {
+				return time.Time{}, err
+			}
+			if e.pad > 0 {
+				j = skipBlanks(j)
+			}
+			continue
+		}
+		for k := 0; k < len(e.literal); k++ {
+			c := e.literal[k]
+			switch {
+			case isSpace(c):
+				j = skipBlanks(j)
+			case j < len(s) && s[j] == c:
+				j++
+			default:
+				return time.Time{}, fmt.Errorf("literal mismatch at position %d: expected '%c'", j, c)
+			}
+		}
+	}
+	if j = skipBlanks(j); j != len(s) {
+		return time.Time{}, fmt.Errorf("unparsed trailing characters at position %d", j)
+	}
+	return result.resolve(base, &o)
+}
+
+// appendSQLLiteral appends literal text to elems, merging it with a preceding literal
+func appendSQLLiteral(elems []sqlElement, offset int, text, literal string) []sqlElement {
+	spec := strings.ReplaceAll(literal, "%", "%%")
+	if n := len(elems); n > 0 && elems[n-1].literal != "" && elems[n-1].offset+len(elems[n-1].text) == offset {
+		last := &elems[n-1]
+		last.text += text
+		last.literal += literal
+		last.spec += spec
+		return elems
+	}
+	return append(elems, sqlElement{offset: offset, text: text, literal: literal, spec: spec})
+}
+
+// postgresKind classifies the template patterns of PostgreSQL's to_char
+type postgresKind uint8
+
+const (
+	postgresNumber postgresKind = iota // Number, spec is the conversion character
+	postgresName                       // Name padded to 9 characters unless FM or TM is given
+	postgresText                       // Other output, spec is the strftime format
+)
+
+// postgresPatterns lists the template patterns of PostgreSQL's to_char, longer patterns first.
+// Patterns whose case selects the case of the output match exactly, others also match in lowercase.
+var postgresPatterns = []struct {
+	name   string
+	kind   postgresKind
+	spec   string
+	digits int    // Digits of a number without FM
+	exact  bool   // Match the case of the name exactly
+	lower  bool   // Lowercase the output
+	reason string // Why the pattern has no strftime equivalent
+}{
+	{name: "HH24", spec: "H", digits: 2},
+	{name: "HH12", spec: "I", digits: 2},
+	{name: "HH", spec: "I", digits: 2},
+	{name: "MI", spec: "M", digits: 2},
+	{name: "SSSSS", reason: "seconds past midnight have no strftime equivalent"},
+	{name: "SSSS", reason: "seconds past midnight have no strftime equivalent"},
+	{name: "SS", spec: "S", digits: 2},
+	{name: "MS", kind: postgresText, spec: "%L"},
+	{name: "US", kind: postgresText, spec: "%f"},
+	{name: "FF1", kind: postgresText, spec: "%1N"},
+	{name: "FF2", kind: postgresText, spec: "%2N"},
+	{name: "FF3", kind: postgresText, spec: "%3N"},
+	{name: "FF4", kind: postgresText, spec: "%4N"},
+	{name: "FF5", kind: postgresText, spec: "%5N"},
+	{name: "FF6", kind: postgresText, spec: "%6N"},
+	{name: "AM", exact: true, kind: postgresText, spec: "%p"},
+	{name: "PM", exact: true, kind: postgresText, spec: "%p"},
+	{name: "am", exact: true, kind: postgresText, spec: "%#p"},
+	{name: "pm", exact: true, kind: postgresText, spec: "%#p"},
+	{name: "A.M.", reason: "meridiem markers with periods have no strftime equivalent"},
+	{name: "P.M.", reason: "meridiem markers with periods have no strftime equivalent"},
+	{name: "Y,YYY", reason: "years with a comma have no strftime equivalent"},
+	{name: "YYYY", spec: "Y", digits: 4},
+	{name: "YYY", reason: "the last three digits of the year have no strftime equivalent"},
+	{name: "YY", spec: "y", digits: 2},
+	{name: "Y", reason: "the last digit of the year has no strftime equivalent"},
+	{name: "IYYY", spec: "G", digits: 4},
+	{name: "IYY", reason: "the last three digits of the ISO 8601 year have no strftime equivalent"},
+	{name: "IY", spec: "g", digits: 2},
+	{name: "IDDD", reason: "the day of the ISO 8601 year has no strftime equivalent"},
+	{name: "ID", spec: "u", digits: 1},
+	{name: "IW", spec: "V", digits: 2},
+	{name: "I", reason: "the last digit of the ISO 8601 year has no strftime equivalent"},
+	{name: "BC", reason: "era indicators have no strftime equivalent"},
+	{name: "AD", reason: "era indicators have no strftime equivalent"},
+	{name: "B.C.", reason: "era indicators have no strftime equivalent"},
+	{name: "A.D.", reason: "era indicators have no strftime equivalent"},
+	{name: "MONTH", exact: true, kind: postgresName, spec: "%^B"},
+	{name: "Month", exact: true, kind: postgresName, spec: "%B"},
+	{name: "month", exact: true, kind: postgresName, spec: "%B", lower: true},
+	{name: "MON", exact: true, kind: postgresText, spec: "%^b"},
+	{name: "Mon", exact: true, kind: postgresText, spec: "%b"},
+	{name: "mon", exact: true, kind: postgresText, spec: "%b", lower: true},
+	{name: "MM", spec: "m", digits: 2},
+	{name: "DAY", exact: true, kind: postgresName, spec: "%^A"},
+	{name: "Day", exact: true, kind: postgresName, spec: "%A"},
+	{name: "day", exact: true, kind: postgresName, spec: "%A", lower: true},
+	{name: "DY", exact: true, kind: postgresText, spec: "%^a"},
+	{name: "Dy", exact: true, kind: postgresText, spec: "%a"},
+	{name: "dy", exact: true, kind: postgresText, spec: "%a", lower: true},
+	{name: "DDD", spec: "j", digits: 3},
+	{name: "DD", spec: "d", digits: 2},
+	{name: "D", reason: "days of week counted from Sunday as 1 have no strftime equivalent"},
+	{name: "WW", reason: "weeks counted from January 1st have no strftime equivalent"},
+	{name: "W", reason: "weeks counted from the first day of the month have no strftime equivalent"},
+	{name: "CC", reason: "centuries counted from 1 have no strftime equivalent"},
+	{name: "J", reason: "Julian days have no strftime equivalent"},
+	{name: "Q", spec: "q", digits: 1},
+	{name: "RM", reason: "Roman numeral months have no strftime equivalent"},
+	{name: "TZH:TZM", kind: postgresText, spec: "%:z"},
+	{name: "TZHTZM", kind: postgresText, spec: "%z"},
+	{name: "TZH", reason: "offset hours on their own have no strftime equivalent"},
+	{name: "TZM", reason: "offset minutes on their own have no strftime equivalent"},
+	{name: "TZ", exact: true, kind: postgresText, spec: "%Z"},
+	{name: "tz", exact: true, kind: postgresText, spec: "%#Z"},
+	{name: "OF", kind: postgresText, spec: "%:::z"},
+}
+
+// postgresElements splits a PostgreSQL to_char template into elements
+func postgresElements(template string) []sqlElement {
+	var elems []sqlElement
+	for i := 0; i < len(template); {
+		start := i
+		switch {
+		case template[i] == '"':
+			// Double-quoted text is literal, with backslash escapes
+			var literal []byte
+			for i++; i < len(template) && template[i] != '"'; i++ {
+				if template[i] == '\\' && i+1 < len(template) {
+					i++
+				}
+				literal = append(literal, template[i])
+			}
+			i = min(i+1, len(template))
+			if len(literal) == 0 {
+				continue
+			}
+			elems = appendSQLLiteral(elems, start, template[start:i], string(literal))
+			continue
+		case strings.HasPrefix(template[i:], `\"`):
+			elems = appendSQLLiteral(elems, start, template[i:i+2], `"`)
+			i += 2
+			continue
+		}
+
+		// FM (fill mode) turns off padding, TM (translation mode) turns off the padding of names
+		// and FX (fixed format) only affects parsing in PostgreSQL
+		fill, translate := false, false
+		for {
+			switch prefix := strings.ToUpper(template[i:min(i+2, len(template))]); prefix {
+			case "FM", "TM", "FX":
+				fill = fill || prefix == "FM"
+				translate = translate || prefix == "TM"
+				i += 2
+				continue
+			}
+			break
+		}
+
+		elem, n := postgresPattern(template[i:], fill, translate)
+		if n == 0 {
+			// Text that isn't a pattern, including prefixes without one, is copied
+			end := max(i, start+1)
+			elems = appendSQLLiteral(elems, start, template[start:end], template[start:end])
+			i = end
+			continue
+		}
+		i += n
+		elem.offset, elem.text = start, template[start:i]
+		elems = append(elems, elem)
+	}
+	return elems
+}
+
+// postgresPattern reads the template pattern at the start of s, with the TH and SP suffixes of numbers.
+// It returns the element and the length it read, 0 if s doesn't start with a pattern.
+func postgresPattern(s string, fill, translate bool) (sqlElement, int) {
+	for _, p := range postgresPatterns {
+		if !strings.HasPrefix(s, p.name) && (p.exact || !strings.HasPrefix(s, strings.ToLower(p.name))) {
+			continue
+		}
+		n := len(p.name)
+
+		switch {
+		case p.reason != "":
+			return sqlElement{reason: p.reason}, n
+		case p.kind == postgresText:
+			return sqlElement{spec: p.spec, lower: p.lower}, n
+		case p.kind == postgresName:
+			e := sqlElement{spec: p.spec, lower: p.lower}
+			if !fill && !translate {
+				e.pad = 9
+			}
+			return e, n
+		}
+
+		// Numbers, unpadded with FM and followed by an ordinal suffix with TH
+		flags, width := "", ""
+		if fill {
+			flags = "-"
+		}
+		switch suffix := s[n:min(n+2, len(s))]; suffix {
+		case "TH", "th":
+			flags = "*"
+			if suffix == "TH" {
+				flags = "^*"
+			}
+			if !fill {
+				width = strconv.Itoa(p.digits)
+			}
+			n += 2
+		case "SP", "sp":
+			return sqlElement{reason: "spelled-out numbers have no strftime equivalent"}, n + 2
+		}
+		return sqlElement{spec: "%" + flags + width + p.spec}, n
+	}
+	return sqlElement{}, 0
+}
+
+// FromPostgreSQL converts a PostgreSQL to_char or to_timestamp template to the equivalent strftime format.
+// FM turns off the padding of numbers and names, and TH adds an ordinal suffix as the '*' flag does.
+// Double-quoted text becomes literal text; like PostgreSQL, other text that isn't a pattern is copied.
+//
+// Patterns without a strftime equivalent, such as J (Julian day) or names padded with blanks as "Month"
+// writes them without FM, are left out of the result and listed in a *TranslationError.
+// The rest of the template is still translated and returned with it.
+func FromPostgreSQL(template string) (string, error) {
+	return translateSQL(template, postgresElements(template))
+}
+
+// PostgreSQLToChar formats time like PostgreSQL's to_char(t, template), using the names of the locale.
+// Names are padded with blanks to 9 characters unless FM or TM is given, and MONTH, Month and month
+// select the case of the name. Patterns without a strftime equivalent are reported as a *TranslationError.
+func PostgreSQLToChar(template string, t time.Time, loc *Locale) (string, error) {
+	return formatSQL(template, postgresElements(template), t, loc)
+}
+
+// PostgreSQLToTimestamp parses s like PostgreSQL's to_timestamp(s, template), using the names of the locale.
+// Names match in any case, numbers may have fewer digits and blanks are skipped around fields.
+// Fields that aren't parsed are taken from 0001-01-01 00:00:00 UTC, and the time is in UTC unless
+// an offset is parsed. Patterns without a strftime equivalent are reported as a *TranslationError.
+func PostgreSQLToTimestamp(template, s string, loc *Locale) (time.Time, error) {
+	return parseSQL(template, postgresElements(template), s, loc)
+}
+
+// mysqlSpecifiers maps the specifiers of MySQL's DATE_FORMAT to the equivalent strftime format,
+// or to the reason there is none when the format is empty
+var mysqlSpecifiers = map[byte]struct{ spec, reason string }{
+	'a': {spec: "%a"},          // Abbreviated weekday name
+	'b': {spec: "%b"},          // Abbreviated month name
+	'c': {spec: "%-m"},         // Month without leading zeros
+	'D': {spec: "%o"},          // Day of month with an ordinal suffix
+	'd': {spec: "%d"},          // Day of month, 2 digits
+	'e': {spec: "%-d"},         // Day of month without leading zeros
+	'f': {spec: "%f"},          // Microseconds
+	'H': {spec: "%H"},          // Hour (00-23)
+	'h': {spec: "%I"},          // Hour (01-12)
+	'I': {spec: "%I"},          // Hour (01-12)
+	'i': {spec: "%M"},          // Minutes
+	'j': {spec: "%j"},          // Day of year
+	'k': {spec: "%-H"},         // Hour (0-23)
+	'l': {spec: "%-I"},         // Hour (1-12)
+	'M': {spec: "%B"},          // Full month name
+	'm': {spec: "%m"},          // Month, 2 digits
+	'p': {spec: "%p"},          // AM or PM
+	'r': {spec: "%I:%M:%S %p"}, // 12-hour time
+	'S': {spec: "%S"},          // Seconds
+	's': {spec: "%S"},          // Seconds
+	'T': {spec: "%H:%M:%S"},    // 24-hour time
+	'U': {spec: "%U"},          // Week (00-53), weeks starting on Sunday
+	'u': {reason: "weeks starting on Monday with four days in the first week (week mode 1) have no strftime equivalent"},
+	'V': {reason: "weeks starting on Sunday counted from 01 (week mode 2) have no strftime equivalent"},
+	'v': {spec: "%V"}, // ISO 8601 week (week mode 3)
+	'W': {spec: "%A"}, // Full weekday name
+	'w': {spec: "%w"}, // Weekday (0-6, Sunday is 0)
+	'X': {reason: "the year of week mode 2 has no strftime equivalent"},
+	'x': {spec: "%G"}, // ISO 8601 week-based year
+	'Y': {spec: "%Y"}, // Year, 4 digits
+	'y': {spec: "%y"}, // Year, 2 digits
+}
+
+// mysqlElements splits a MySQL DATE_FORMAT format into elements
+func mysqlElements(format string) []sqlElement {
+	var elems []sqlElement
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 >= len(format) {
+			elems = appendSQLLiteral(elems, i, format[i:i+1], format[i:i+1])
+			continue
+		}
+		i++
+		s, ok := mysqlSpecifiers[format[i]]
+		if !ok {
+			// %x writes x for any x not listed, including %%
+			elems = appendSQLLiteral(elems, i-1, format[i-1:i+1], format[i:i+1])
+			continue
+		}
+		elems = append(elems, sqlElement{offset: i - 1, text: format[i-1 : i+1], spec: s.spec, reason: s.reason})
+	}
+	return elems
+}
+
+// FromMySQL converts a MySQL DATE_FORMAT or STR_TO_DATE format to the equivalent strftime format.
+// Specifiers without a strftime equivalent, such as %X, are left out of the result and listed in
+// a *TranslationError. The rest of the format is still translated and returned with it.
+func FromMySQL(format string) (string, error) {
+	return translateSQL(format, mysqlElements(format))
+}
+
+// MySQLDateFormat formats time like MySQL's DATE_FORMAT(t, format), using the names of the locale.
+// Specifiers without a strftime equivalent are reported as a *TranslationError.
+func MySQLDateFormat(format string, t time.Time, loc *Locale) (string, error) {
+	return formatSQL(format, mysqlElements(format), t, loc)
+}
+
+// MySQLStrToDate parses s like MySQL's STR_TO_DATE(s, format), using the names of the locale.
+// Names match in any case, numbers may have fewer digits and blanks are skipped around fields.
+// Fields that aren't parsed are taken from 0001-01-01 00:00:00 UTC, and the time is in UTC. Specifiers without a strftime equivalent are
+// reported as a *TranslationError.
+func MySQLStrToDate(format, s string, loc *Locale) (time.Time, error) {
+	return parseSQL(format, mysqlElements(format), s, loc)
+}
@@ -0,0 +1,259 @@
+package strftime
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// untranslatedTexts returns the texts listed by a *TranslationError, or nil if err isn't one
+func untranslatedTexts(err error) []string {
+	var terr *TranslationError
+	if !errors.As(err, &terr) {
+		return nil
+	}
+	var texts []string
+	for _, u := range terr.Untranslated {
+		texts = append(texts, u.Text)
+	}
+	return texts
+}
+
+func TestPostgreSQLToChar(t *testing.T) {
+	tm := time.Date(2026, time.October, 16, 9, 5, 7, 123456789, time.UTC)
+
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{"YYYY-MM-DD HH24:MI:SS.MS", "2026-10-16 09:05:07.123"},
+		{"yyyy-mm-dd hh24:mi:ss.us", "2026-10-16 09:05:07.123456"},
+		{"FMDay, FMDD FMMonth YYYY", "Friday, 16 October 2026"},
+		{"Day, DD Month YYYY", "Friday   , 16 October   2026"},
+		{"MONTH|Month|month|TMMonth", "OCTOBER  |October  |october  |October"},
+		{"FMMONTH Mon mon DY Dy dy", "OCTOBER Oct oct FRI Fri fri"},
+		{"HH12:MI AM am PM pm FMHH12 HH", "09:05 AM am AM am 9 09"},
+		{"DDth FMDDth DDTH FMMMth", "16th 16th 16TH 10th"},
+		{"IYYY-IW-ID IY Q DDD FF3 FF1", "2026-42-5 26 4 289 123 1"},
+		{`"Quarter" Q, \"q\" 100%`, `Quarter 4, "4" 100%`},
+		{"TZ tz OF TZH:TZM TZHTZM", "UTC utc +00 +00:00 +0000"},
+		{`FXYYYY"FM"`, "2026FM"},
+	}
+
+	for _, tt := range tests {
+		got, err := PostgreSQLToChar(tt.template, tm, nil)
+		if err != nil {
+			t.Errorf("PostgreSQLToChar(%q) returned error: %v", tt.template, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("PostgreSQLToChar(%q): got [%s], expected [%s]", tt.template, got, tt.expected)
+		}
+	}
+
+	first := time.Date(2026, time.March, 1, 14, 30, 0, 0, time.FixedZone("IST", 5*3600+1800))
+	if got, _ := PostgreSQLToChar(`FMDDth "of" FMMonth, FMHH12:MI pm OF`, first, nil); got != "1st of March, 2:30 pm +05:30" {
+		t.Errorf("PostgreSQLToChar with an ordinal: got [%s]", got)
+	}
+
+	got, err := PostgreSQLToChar("YYYY J WW-D Y,YYY RM SSSS DDSP", tm, nil)
+	if got != "" {
+		t.Errorf("PostgreSQLToChar should return no output on error, got [%s]", got)
+	}
+	if texts, expected := untranslatedTexts(err), []string{"J", "WW", "D", "Y,YYY", "RM", "SSSS", "DDSP"}; !reflect.DeepEqual(texts, expected) {
+		t.Errorf("Untranslated patterns: got %q, expected %q", texts, expected)
+	}
+}
+
+func TestFromPostgreSQL(t *testing.T) {
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{"YYYY-MM-DD HH24:MI:SS.MS", "%Y-%m-%d %H:%M:%S.%L"},
+		{"FMDay, FMDD FMMonth YYYY", "%A, %-d %B %Y"},
+		{"TMMONTH TMDay", "%^B %A"},
+		{`YYYY-MM-DD"T"HH24:MI:SSTZH:TZM`, "%Y-%m-%dT%H:%M:%S%:z"},
+		{"hh24:mi am", "%H:%M %#p"},
+		{"DDth FMDDth DDTH", "%*2d %*d %^*2d"},
+		{"Mon DY tz, 100%", "%b %^a %#Z, 100%%"},
+	}
+
+	for _, tt := range tests {
+		format, err := FromPostgreSQL(tt.template)
+		if err != nil {
+			t.Errorf("FromPostgreSQL(%q) returned error: %v", tt.template, err)
+			continue
+		}
+		if format != tt.expected {
+			t.Errorf("FromPostgreSQL(%q): got [%s], expected [%s]", tt.template, format, tt.expected)
+		}
+	}
+
+	format, err := FromPostgreSQL("Day DD month mon J")
+	if format != " %d   " {
+		t.Errorf("FromPostgreSQL should translate the rest of the template, got [%s]", format)
+	}
+	if texts, expected := untranslatedTexts(err), []string{"Day", "month", "mon", "J"}; !reflect.DeepEqual(texts, expected) {
+		t.Errorf("Untranslated patterns: got %q, expected %q", texts, expected)
+	}
+}
+
+func TestPostgreSQLToTimestamp(t *testing.T) {
+	tests := []struct {
+		template string
+		input    string
+		expected string
+	}{
+		{"YYYY-MM-DD HH24:MI:SS.MS", "2026-10-16 09:05:07.123", "2026-10-16T09:05:07.123Z"},
+		{"Day, DD Month YYYY", "Friday   , 16 October   2026", "2026-10-16T00:00:00Z"},
+		{"FMDay, FMDD FMMonth YYYY", "friday,  6 OCTOBER 2026", "2026-10-06T00:00:00Z"},
+		{"DD Mon YYYY HH12:MI am", "05 mar 2026 2:30 pm", "2026-03-05T14:30:00Z"},
+		{`YYYY-MM-DD"T"HH24:MI:SSTZH:TZM`, "2026-03-05T14:30:00+05:30", "2026-03-05T14:30:00+05:30"},
+		{"HH24:MI", "7:45", "0001-01-01T07:45:00Z"},
+		{"FMDDth FMMonth YYYY", "1st March 2026", "2026-03-01T00:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		parsed, err := PostgreSQLToTimestamp(tt.template, tt.input, nil)
+		if err != nil {
+			t.Errorf("PostgreSQLToTimestamp(%q, %q) returned error: %v", tt.template, tt.input, err)
+			continue
+		}
+		if got := parsed.Format(time.RFC3339Nano); got != tt.expected {
+			t.Errorf("PostgreSQLToTimestamp(%q, %q): got [%s], expected [%s]", tt.template, tt.input, got, tt.expected)
+		}
+	}
+
+	invalid := []struct {
+		template string
+		input    string
+	}{
+		{"YYYY-MM-DD", "2026/10/16"},
+		{"YYYY-MM-DD", "2026-10-16 extra"},
+		{"J", "2461330"},
+	}
+	for _, tt := range invalid {
+		if parsed, err := PostgreSQLToTimestamp(tt.template, tt.input, nil); err == nil {
+			t.Errorf("PostgreSQLToTimestamp(%q, %q) expected error, but got %v", tt.template, tt.input, parsed)
+		}
+	}
+}
+
+func TestMySQLDateFormat(t *testing.T) {
+	// The examples of MySQL's DATE_FORMAT documentation
+	tests := []struct {
+		format   string
+		t        time.Time
+		expected string
+	}{
+		{"%W %M %Y", time.Date(2009, time.October, 4, 22, 23, 0, 0, time.UTC), "Sunday October 2009"},
+		{"%H:%i:%s", time.Date(2007, time.October, 4, 22, 23, 0, 0, time.UTC), "22:23:00"},
+		{"%D %y %a %d %m %b %j", time.Date(1900, time.October, 4, 22, 23, 0, 0, time.UTC), "4th 00 Thu 04 10 Oct 277"},
+		{"%H %k %I %r %T %S %w", time.Date(1997, time.October, 4, 22, 23, 0, 0, time.UTC), "22 22 10 10:23:00 PM 22:23:00 00 6"},
+		{"%c/%e %l%p %f %v %x %U %q%% %", time.Date(2026, time.January, 2, 9, 5, 7, 123456000, time.UTC), "1/2 9AM 123456 01 2026 00 q% %"},
+	}
+
+	for _, tt := range tests {
+		got, err := MySQLDateFormat(tt.format, tt.t, nil)
+		if err != nil {
+			t.Errorf("MySQLDateFormat(%q) returned error: %v", tt.format, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("MySQLDateFormat(%q): got [%s], expected [%s]", tt.format, got, tt.expected)
+		}
+	}
+
+	_, err := MySQLDateFormat("%X %V %u", time.Now(), nil)
+	if texts, expected := untranslatedTexts(err), []string{"%X", "%V", "%u"}; !reflect.DeepEqual(texts, expected) {
+		t.Errorf("Untranslated specifiers: got %q, expected %q", texts, expected)
+	}
+}
+
+func TestFromMySQL(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%Y-%m-%d %H:%i:%s", "%Y-%m-%d %H:%M:%S"},
+		{"%M %D, %Y", "%B %o, %Y"},
+		{"%e.%c.%y %k:%i", "%-d.%-m.%y %-H:%M"},
+		{"%r %q 50%%", "%I:%M:%S %p q 50%%"},
+	}
+
+	for _, tt := range tests {
+		format, err := FromMySQL(tt.format)
+		if err != nil {
+			t.Errorf("FromMySQL(%q) returned error: %v", tt.format, err)
+			continue
+		}
+		if format != tt.expected {
+			t.Errorf("FromMySQL(%q): got [%s], expected [%s]", tt.format, format, tt.expected)
+		}
+	}
+
+	format, err := FromMySQL("%x-W%v %X-W%V")
+	if format != "%G-W%V -W" {
+		t.Errorf("FromMySQL should translate the rest of the format, got [%s]", format)
+	}
+	if texts, expected := untranslatedTexts(err), []string{"%X", "%V"}; !reflect.DeepEqual(texts, expected) {
+		t.Errorf("Untranslated specifiers: got %q, expected %q", texts, expected)
+	}
+}
+
+func TestMySQLStrToDate(t *testing.T) {
+	// The examples of MySQL's STR_TO_DATE documentation
+	tests := []struct {
+		format   string
+		input    string
+		expected string
+	}{
+		{"%d,%m,%Y", "01,5,2013", "2013-05-01T00:00:00Z"},
+		{"%M %d,%Y", "May 1, 2013", "2013-05-01T00:00:00Z"},
+		{"a%h:%i:%s", "a09:30:17", "0001-01-01T09:30:17Z"},
+		{"%Y-%m-%d %H:%i:%s.%f", "2026-10-16 09:05:07.25", "2026-10-16T09:05:07.25Z"},
+		{"%W, %D of %M %Y %r", "friday, 16th of october 2026 02:05:07 PM", "2026-10-16T14:05:07Z"},
+	}
+
+	for _, tt := range tests {
+		parsed, err := MySQLStrToDate(tt.format, tt.input, nil)
+		if err != nil {
+			t.Errorf("MySQLStrToDate(%q, %q) returned error: %v", tt.format, tt.input, err)
+			continue
+		}
+		if got := parsed.Format(time.RFC3339Nano); got != tt.expected {
+			t.Errorf("MySQLStrToDate(%q, %q): got [%s], expected [%s]", tt.format, tt.input, got, tt.expected)
+		}
+	}
+
+	if parsed, err := MySQLStrToDate("%d,%m,%Y", "01/5/2013", nil); err == nil {
+		t.Errorf("MySQLStrToDate with a mismatched literal expected error, but got %v", parsed)
+	}
+}
+
+func TestSQLDate_ISOWeekRoundTrip(t *testing.T) {
+	// Dates around New Year, where the ISO week-based year and the calendar year differ
+	for _, date := range []time.Time{
+		time.Date(2024, time.December, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, time.January, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC),
+	} {
+		formatted, err := PostgreSQLToChar("IYYY-IW-ID", date, nil)
+		if err != nil {
+			t.Fatalf("PostgreSQLToChar returned error: %v", err)
+		}
+		if parsed, err := PostgreSQLToTimestamp("IYYY-IW-ID", formatted, nil); err != nil || !parsed.Equal(date) {
+			t.Errorf("PostgreSQLToTimestamp(%q): got [%v] (%v), expected [%v]", formatted, parsed, err, date)
+		}
+
+		formatted, err = MySQLDateFormat("%x %v %W", date, nil)
+		if err != nil {
+			t.Fatalf("MySQLDateFormat returned error: %v", err)
+		}
+		if parsed, err := MySQLStrToDate("%x %v %W", formatted, nil); err != nil || !parsed.Equal(date) {
+			t.Errorf("MySQLStrToDate(%q): got [%v] (%v), expected [%v]", formatted, parsed, err, date)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package strftime
+
+import (
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// TokenKind classifies a Token
+type TokenKind int
+
+const (
+	TokenLiteral   TokenKind = iota // Literal text, or a specifier writing fixed text: %%, %n and %t
+	TokenSpecifier                  // Conversion specifier, built in or registered in a SpecifierSet
+	TokenComposite                  // Specifier expanding to a pattern, such as %c or %F
+	TokenUnknown                    // Unknown specifier, or a specifier the format ends in the middle of
+)
+
+func (k TokenKind) String() string {
+	switch k {
+	case TokenLiteral:
+		return "literal"
+	case TokenSpecifier:
+		return "specifier"
+	case TokenComposite:
+		return "composite"
+	case TokenUnknown:
+		return "unknown"
+	}
+	return "TokenKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Token is a piece of a format string: a run of literal text or a single specifier
+type Token struct {
+	Kind      TokenKind
+	Start     int    // Byte offset of the token in the format
+	End       int    // Byte offset just past the token
+	Text      string // Token as written, format[Start:End]
+	Flags     string // GNU flags as written, such as "-" or "^_"
+	Width     int    // Field width, 0 if none was given
+	Colons    int    // Number of colons, as in %::z
+	Alt       byte   // POSIX 'E' or 'O' modifier, 0 if none was given
+	Specifier string // Conversion character, such as "d" or a registered rune; empty for literal text and incomplete specifiers
+}
+
+// String returns the token as written in the format
+func (t Token) String() string {
+	return t.Text
+}
+
+// Modifiers returns the flags, width, colons and E/O modifier of the token as passed to a FormatFunc
+func (t Token) Modifiers() Modifiers {
+	m := Modifiers{Width: t.Width, Colons: t.Colons, Alt: t.Alt}
+	for k := 0; k < len(t.Flags); k++ {
+		switch c := t.Flags[k]; c {
+		case '-', '_', '0': // The last padding flag wins
+			m.Pad = c
+		case '^':
+			m.Upper = true
+		case '#':
+			m.Swap = true
+		case '*':
+			m.Ordinal = true
+		}
+	}
+	return m
+}
+
+// spec writes a specifier with the token's colons and conversion and the given flags, width and modifier
+func (t Token) spec(flags string, width int, alt byte) string {
+	var b strings.Builder
+	b.WriteByte('%')
+	b.WriteString(flags)
+	if width > 0 {
+		b.WriteString(strconv.Itoa(width))
+	}
+	b.WriteString(strings.Repeat(":", t.Colons))
+	if alt != 0 {
+		b.WriteByte(alt)
+	}
+	b.WriteString(t.Specifier)
+	return b.String()
+}
+
+// Tokenize splits format into tokens as StrftimeL and ParseL read it, with the specifiers registered
+// in the set given with WithSpecifiers and in DefaultSpecifiers. All tokens are returned, along with a
+// *FormatError for the first unknown or incomplete specifier if there is one.
+func Tokenize(format string, opts ...Option) ([]Token, error) {
+	var tokens []Token
+	var err error
+	for t := range Tokens(format, opts...) {
+		if t.Kind == TokenUnknown && err == nil {
+			err = &FormatError{Format: format, Offset: t.Start, Specifier: t.Text, Incomplete: t.Specifier == ""}
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, err
+}
+
+// Tokens returns an iterator over the tokens of format, as Tokenize splits it
+func Tokens(format string, opts ...Option) iter.Seq[Token] {
+	return func(yield func(Token) bool) {
+		o := newOptions(opts)
+		for t := range tokens(format, &o) {
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// tokens returns an iterator over the tokens of format and the ops the specifiers stand for.
+// Runs of literal text come with an empty op.
+func tokens(format string, opts *options) iter.Seq2[Token, op] {
+	return func(yield func(Token, op) bool) {
+		for i := 0; i < len(format); {
+			if format[i] != '%' {
+				start := i
+				for i < len(format) && format[i] != '%' {
+					i++
+				}
+				if !yield(Token{Kind: TokenLiteral, Start: start, End: i, Text: format[start:i]}, op{}) {
+					return
+				}
+				continue
+			}
+			t, o := scanToken(format, i, opts)
+			if !yield(t, o) {
+				return
+			}
+			i = t.End
+		}
+	}
+}
+
+// scanToken reads the specifier that starts at format[i] == '%', returning its token and the op it stands for.
+//
+// Unknown conversions yield an opUnknown op holding the conversion character,
+// with empty text when the format ends before a conversion character is found.
+func scanToken(format string, i int, opts *options) (Token, op) {
+	var m Modifiers
+	flagsEnd, j := scanModifiers(format, i, &m)
+	t := Token{Kind: TokenUnknown, Start: i, Flags: format[i+1 : flagsEnd], Width: m.Width, Colons: m.Colons, Alt: m.Alt}
+	var o op
+	size := scanConversion(format, j, &m, opts, &o)
+	t.End, t.Text, t.Specifier = j+size, format[i:j+size], format[j:j+size]
+
+	switch o.kind {
+	case opUnknown:
+		t.Kind = TokenUnknown
+	case opLiteral:
+		t.Kind = TokenLiteral
+	case opComposite:
+		t.Kind = TokenComposite
+	default:
+		t.Kind = TokenSpecifier
+	}
+	return t, o
+}
+
+// scanModifiers reads the modifiers of the specifier that starts at format[i] == '%' into m. It returns the
+// index just past the flags and the index of the conversion character, len(format) if the format ends first.
+//
+// This is the grammar of specifiers for formatting and parsing: '%', flags, width, colons, an E or O modifier
+// and the conversion character. A '%' between the modifier and the conversion is skipped, as in %E%Y,
+// unless it is followed by another '%'.
+func scanModifiers(format string, i int, m *Modifiers) (flagsEnd, j int) {
+	// GNU flags, in any order and combination
+	j = i + 1
+flags:
+	for ; j < len(format); j++ {
+		switch c := format[j]; c {
+		case '-', '_', '0': // The last padding flag wins
+			m.Pad = c
+		case '^':
+			m.Upper = true
+		case '#':
+			m.Swap = true
+		case '*':
+			m.Ordinal = true
+		default:
+			break flags
+		}
+	}
+	flagsEnd = j
+
+	// Field width, such as %10A or the precision in %3N
+	for j < len(format) && format[j] >= '0' && format[j] <= '9' {
+		m.Width = m.Width*10 + int(format[j]-'0')
+		j++
+	}
+
+	// Colons of %:z, %::z and %:::z
+	for j < len(format) && format[j] == ':' {
+		m.Colons++
+		j++
+	}
+
+	// POSIX locale extensions
+	if j < len(format) && (format[j] == 'E' || format[j] == 'O') {
+		m.Alt = format[j]
+		j++
+		if j+1 < len(format) && format[j] == '%' && format[j+1] != '%' {
+			j++
+		}
+	}
+	return flagsEnd, j
+}
+
+// scanConversion sets o to the op the conversion at format[j] stands for under m and returns the size of the
+// conversion, 0 if the format ends before it. Built-in conversions take precedence over registered ones, apart
+// from the registrable ones. Unknown conversions yield an opUnknown op holding the conversion character.
+func scanConversion(format string, j int, m *Modifiers, opts *options, o *op) int {
+	if j >= len(format) {
+		*o = op{kind: opUnknown}
+		return 0
+	}
+	def := directiveOp(o, format[j:j+1], m, opts)
+	if o.kind == opUnknown || def != nil && def.registrable {
+		if spec, n, ok := opts.specifier(format[j:]); ok {
+			*o = op{kind: opCustom, custom: spec.Format, mods: *m}
+			return n
+		}
+	}
+	return 1
+}
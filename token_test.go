@@ -0,0 +1,143 @@
+package strftime
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens, err := Tokenize("at %-d.%_3N %^c%:z%EY%E%Y%%%n")
+	if err != nil {
+		t.Fatalf("Tokenize returned error: %v", err)
+	}
+
+	expected := []Token{
+		{Kind: TokenLiteral, Start: 0, End: 3, Text: "at "},
+		{Kind: TokenSpecifier, Start: 3, End: 6, Text: "%-d", Flags: "-", Specifier: "d"},
+		{Kind: TokenLiteral, Start: 6, End: 7, Text: "."},
+		{Kind: TokenSpecifier, Start: 7, End: 11, Text: "%_3N", Flags: "_", Width: 3, Specifier: "N"},
+		{Kind: TokenLiteral, Start: 11, End: 12, Text: " "},
+		{Kind: TokenComposite, Start: 12, End: 15, Text: "%^c", Flags: "^", Specifier: "c"},
+		{Kind: TokenSpecifier, Start: 15, End: 18, Text: "%:z", Colons: 1, Specifier: "z"},
+		{Kind: TokenSpecifier, Start: 18, End: 21, Text: "%EY", Alt: 'E', Specifier: "Y"},
+		{Kind: TokenSpecifier, Start: 21, End: 25, Text: "%E%Y", Alt: 'E', Specifier: "Y"},
+		{Kind: TokenLiteral, Start: 25, End: 27, Text: "%%", Specifier: "%"},
+		{Kind: TokenLiteral, Start: 27, End: 29, Text: "%n", Specifier: "n"},
+	}
+	if len(tokens) != len(expected) {
+		t.Fatalf("Tokenize: got %d tokens %v, expected %d", len(tokens), tokens, len(expected))
+	}
+	for k, tok := range tokens {
+		if tok != expected[k] {
+			t.Errorf("Token %d: got %+v, expected %+v", k, tok, expected[k])
+		}
+	}
+}
+
+func TestTokenize_Errors(t *testing.T) {
+	tests := []struct {
+		format     string
+		offset     int
+		specifier  string
+		incomplete bool
+	}{
+		{"%Y-%Q", 3, "%Q", false},
+		{"%Y %:d", 3, "%:d", false},
+		{"%Y %-", 3, "%-", true},
+		{"%E", 0, "%E", true},
+	}
+
+	for _, tt := range tests {
+		tokens, err := Tokenize(tt.format)
+		var fe *FormatError
+		if !errors.As(err, &fe) {
+			t.Errorf("Tokenize(%q): expected a *FormatError, got %v", tt.format, err)
+			continue
+		}
+		if fe.Offset != tt.offset || fe.Specifier != tt.specifier || fe.Incomplete != tt.incomplete {
+			t.Errorf("Tokenize(%q): got %+v", tt.format, fe)
+		}
+		// All tokens are returned with the error
+		var b strings.Builder
+		for _, tok := range tokens {
+			b.WriteString(tok.String())
+		}
+		if b.String() != tt.format {
+			t.Errorf("Tokenize(%q): tokens write [%s]", tt.format, b.String())
+		}
+	}
+}
+
+func TestTokens(t *testing.T) {
+	formats := []string{
+		"",
+		"plain text",
+		"%Y-%m-%dT%H:%M:%S.%f%:z",
+		"%^#-_0*10::Ez %O%%H %E%%Y 100%",
+		"日付 %A %★ %",
+	}
+
+	for _, format := range formats {
+		var b strings.Builder
+		end := 0
+		for tok := range Tokens(format) {
+			if tok.Start != end || format[tok.Start:tok.End] != tok.Text {
+				t.Errorf("Tokens(%q): token %+v doesn't follow offset %d", format, tok, end)
+			}
+			end = tok.End
+			b.WriteString(tok.String())
+		}
+		if b.String() != format {
+			t.Errorf("Tokens(%q): got [%s]", format, b.String())
+		}
+	}
+
+	// Stopping early
+	count := 0
+	for range Tokens("%Y-%m-%d") {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("Tokens should stop when the loop breaks, got %d tokens", count)
+	}
+}
+
+func TestTokenize_CustomSpecifiers(t *testing.T) {
+	set := NewSpecifierSet()
+	if err := set.Register('★', func(dst []byte, _ time.Time, _ *Locale, _ Modifiers) []byte { return dst }, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tokens, err := Tokenize("%^★", WithSpecifiers(set))
+	if err != nil {
+		t.Fatalf("Tokenize returned error: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].Kind != TokenSpecifier || tokens[0].Specifier != "★" || tokens[0].Flags != "^" {
+		t.Errorf("Tokenize with a custom specifier: got %+v", tokens)
+	}
+	if _, err := Tokenize("%^★"); err == nil {
+		t.Error("Tokenize without the set should report the specifier as unknown")
+	}
+}
+
+func TestTokenize_SharedGrammar(t *testing.T) {
+	// Formatting and parsing read "%E%Y" as "%EY"
+	tm := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+	if got := Strftime("%E%Y-%m-%d", tm); got != "2025-03-15" {
+		t.Errorf("Strftime(%%E%%Y): got [%s], expected [%s]", got, "2025-03-15")
+	}
+	parsed, err := Parse("%E%Y-%m-%d", "2025-03-15")
+	if err != nil || parsed.Year() != 2025 || parsed.Month() != time.March || parsed.Day() != 15 {
+		t.Errorf("Parse(%%E%%Y): got %v (%v), expected 2025-03-15", parsed, err)
+	}
+
+	tok := Token{Flags: "-_", Width: 5, Alt: 'O', Specifier: "d"}
+	if got := tok.Modifiers(); got != (Modifiers{Pad: '_', Width: 5, Alt: 'O'}) {
+		t.Errorf("Modifiers: got %+v", got)
+	}
+}
@@ -0,0 +1,54 @@
+package strftime
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Untranslated is an element of a pattern or format that a translator could not represent
+type Untranslated struct {
+	Offset int    // Byte offset of the element in the source
+	Text   string // Element as written in the source, such as "G" or "%U"
+	Reason string // Why the element has no equivalent
+}
+
+// TranslationError lists the elements a translator left out of its result
+type TranslationError struct {
+	Source       string         // Pattern or format being translated
+	Untranslated []Untranslated // Elements left out, in the order they appear in Source
+}
+
+func (e *TranslationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "strftime: cannot translate %q:", e.Source)
+	for i, u := range e.Untranslated {
+		if i > 0 {
+			b.WriteByte(';')
+		}
+		fmt.Fprintf(&b, " %q at offset %d (%s)", u.Text, u.Offset, u.Reason)
+	}
+	return b.String()
+}
+
+// translation collects the output of a translator and the elements it could not represent
+type translation struct {
+	source string
+	out    strings.Builder
+	err    *TranslationError
+}
+
+// skip records source[start:end] as untranslated
+func (t *translation) skip(start, end int, reason string) {
+	if t.err == nil {
+		t.err = &TranslationError{Source: t.source}
+	}
+	t.err.Untranslated = append(t.err.Untranslated, Untranslated{Offset: start, Text: t.source[start:end], Reason: reason})
+}
+
+// result returns the translated text and a *TranslationError if anything was left out
+func (t *translation) result() (string, error) {
+	if t.err != nil {
+		return t.out.String(), t.err
+	}
+	return t.out.String(), nil
+}
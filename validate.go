@@ -0,0 +1,272 @@
+package strftime
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Severity ranks a Diagnostic
+type Severity int
+
+const (
+	SeverityInfo    Severity = iota // The format works, with a caveat worth knowing
+	SeverityWarning                 // The format works, but likely not as intended or not for parsing
+	SeverityError                   // The format has an unknown or incomplete specifier
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Diagnostic is a problem Validate found in a format
+type Diagnostic struct {
+	Offset    int    // Byte offset of the '%' starting the specifier
+	Specifier string // Specifier as written, such as "%Q" or "%-A"
+	Severity  Severity
+	Message   string // What is wrong
+	Fix       string // Suggested fix
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s at offset %d: %s %s (%s)", d.Severity, d.Offset, d.Specifier, d.Message, d.Fix)
+}
+
+// validationTimes are formatted to tell whether a flag or modifier changes the output.
+// They differ in every field, and the second has small values that padding shows and a zero UTC offset.
+var validationTimes = [...]time.Time{
+	time.Date(2009, time.November, 17, 20, 34, 58, 123456789, time.FixedZone("EST", -5*3600)),
+	time.Date(33, time.February, 3, 4, 5, 6, 7000000, time.UTC),
+}
+
+// parseableEquivalents holds formats ParseL can read for specifiers it can't
+var parseableEquivalents = map[byte]string{
+	'C': "%Y",
+	'k': "%_H",
+	'l': "%_I",
+	'n': "a literal newline",
+	't': "a literal tab",
+	'v': "%e-%b-%Y",
+}
+
+// Validate reports the problems of format under the given options, using the specifier tables of
+// StrftimeL and ParseL:
+//   - unknown and incomplete specifiers, including a trailing '%'
+//   - E and O modifiers on specifiers that have no alternative representation
+//   - flags and widths that don't change the output of their specifier
+//   - specifiers ParseL can't read back
+//   - combinations that don't survive a round trip through ParseL, such as %I without %p
+//
+// Diagnostics are ordered by offset. A format without problems returns nil.
+func Validate(format string, opts ...Option) []Diagnostic {
+	o := newOptions(opts)
+	var diags []Diagnostic
+	report := func(start, next int, severity Severity, message, fix string) {
+		diags = append(diags, Diagnostic{
+			Offset:    start,
+			Specifier: format[start:next],
+			Severity:  severity,
+			Message:   message,
+			Fix:       fix,
+		})
+	}
+
+	// A copy of the locale with eras and alternative digits shows whether E and O change anything
+	probe := o
+	locale := *o.locale
+	locale.Eras = []Era{{Start: time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC), Name: "E", Offset: 1}}
+	locale.EraDateTimeFormat, locale.EraDateFormat, locale.EraTimeFormat = "%EY", "%EY", "%EY"
+	locale.AltDigits = []string{"o0", "o1", "o2", "o3", "o4", "o5", "o6", "o7", "o8", "o9"}
+	probe.locale = &locale
+
+	var usage formatUsage
+	for tok, d := range tokens(format, &o) {
+		start, next, spec := tok.Start, tok.End, tok.Text
+		switch {
+		case tok.Kind == TokenUnknown && tok.Specifier == "":
+			if spec == "%" {
+				report(start, next, SeverityError, "is a dangling '%' at the end of the format", `use "%%" for a literal '%'`)
+			} else {
+				report(start, next, SeverityError, "is incomplete", `complete the specifier, or use "%%" for a literal '%'`)
+			}
+			continue
+		case tok.Kind == TokenUnknown:
+			report(start, next, SeverityError, "is an unknown specifier", fmt.Sprintf("use %q for literal text", "%"+spec))
+			continue
+		case tok.Specifier == "" || spec == "%%":
+			continue
+		}
+
+		// Modifiers and flags are checked one at a time by removing them from the specifier
+		if tok.Alt != 0 {
+			if without := tok.spec(tok.Flags, tok.Width, 0); ignored(spec, without, &probe) {
+				report(start, next, SeverityWarning, fmt.Sprintf("has an %c modifier that has no effect", tok.Alt), "use "+without)
+			}
+		}
+		for k := range len(tok.Flags) {
+			if without := tok.spec(tok.Flags[:k]+tok.Flags[k+1:], tok.Width, tok.Alt); ignored(spec, without, &probe) {
+				report(start, next, SeverityWarning, fmt.Sprintf("has a '%c' flag that has no effect", tok.Flags[k]), "use "+without)
+			}
+		}
+		if tok.Width > 0 {
+			if without := tok.spec(tok.Flags, 0, tok.Alt); ignored(spec, without, &probe) {
+				report(start, next, SeverityWarning, "has a width that has no effect", "use "+without)
+			}
+		}
+
+		if !parseable(spec, &o) {
+			fix := "format it separately from the fields to be parsed"
+			if equivalent, ok := parseableEquivalents[spec[len(spec)-1]]; ok && len(spec) == 2 {
+				fix = "use " + equivalent
+			}
+			report(start, next, SeverityWarning, "can't be read back by ParseL", fix)
+		}
+
+		usage.add(d, start, next, &o)
+	}
+
+	for _, rt := range usage.roundTrip() {
+		report(rt.start, rt.next, rt.severity, rt.message, rt.fix)
+	}
+	slices.SortStableFunc(diags, func(a, b Diagnostic) int { return cmp.Compare(a.Offset, b.Offset) })
+	return diags
+}
+
+// ignored reports whether the specifiers spec and without write the same text for all validationTimes
+func ignored(spec, without string, opts *options) bool {
+	if o, _ := scanDirective(without, 0, opts); o.kind == opUnknown {
+		return false
+	}
+	for _, t := range validationTimes {
+		if string(appendFormat(nil, spec, t, opts)) != string(appendFormat(nil, without, t, opts)) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseable reports whether ParseL reads back the whole output of spec for all validationTimes
+func parseable(spec string, opts *options) bool {
+	for _, t := range validationTimes {
+		s := string(appendFormat(nil, spec, t, opts))
+		var result parseResult
+		if j, err := parseInto(&result, spec, s, 0, opts); err != nil || j != len(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatUsage records the first specifier writing each kind of value a round trip depends on,
+// with composite specifiers standing for the values of their expansion
+type formatUsage struct {
+	hour12, ampm, hour24   *directiveSpan
+	year, year2, isoYear   *directiveSpan
+	isoWeek, zone, offset  *directiveSpan
+	weekNumber, weekdayAny *directiveSpan
+}
+
+// directiveSpan locates a specifier in the format
+type directiveSpan struct {
+	start, next int
+}
+
+// add records the values written by o, read from format[start:next]
+func (u *formatUsage) add(o op, start, next int, opts *options) {
+	set := func(p **directiveSpan) {
+		if *p == nil {
+			*p = &directiveSpan{start, next}
+		}
+	}
+	switch o.kind {
+	case opComposite:
+		if opts.depth >= maxCompositeDepth {
+			return
+		}
+		nested := *opts
+		nested.depth++
+		for _, sub := range tokens(o.text, &nested) {
+			u.add(sub, start, next, &nested)
+		}
+	case opEra:
+		set(&u.year)
+	case opName:
+		switch o.names {
+		case nameAMPM:
+			set(&u.ampm)
+		case nameWeekdayFull, nameWeekdayAbbrev:
+			set(&u.weekdayAny)
+		}
+	case opLayout:
+		set(&u.zone)
+	case opOffset:
+		set(&u.offset)
+	case opNumber:
+		switch o.field {
+		case fieldHour12:
+			set(&u.hour12)
+		case fieldHour:
+			set(&u.hour24)
+		case fieldYear:
+			set(&u.year)
+		case fieldYear2:
+			set(&u.year2)
+		case fieldISOYear, fieldISOYear2:
+			set(&u.isoYear)
+		case fieldISOWeek:
+			set(&u.isoWeek)
+		case fieldWeekSunday, fieldWeekMonday:
+			set(&u.weekNumber)
+		case fieldWeekday, fieldWeekdayISO:
+			set(&u.weekdayAny)
+		}
+	}
+}
+
+// roundTripProblem is a diagnostic about the format as a whole, reported at one of its specifiers
+type roundTripProblem struct {
+	start, next int
+	severity    Severity
+	message     string
+	fix         string
+}
+
+// roundTrip returns the problems of writing a time and reading it back with ParseL
+func (u *formatUsage) roundTrip() []roundTripProblem {
+	var problems []roundTripProblem
+	add := func(at *directiveSpan, severity Severity, message, fix string) {
+		problems = append(problems, roundTripProblem{at.start, at.next, severity, message, fix})
+	}
+	if u.hour12 != nil && u.ampm == nil && u.hour24 == nil {
+		add(u.hour12, SeverityWarning, "writes a 12-hour clock hour without %p, so morning and evening can't be told apart",
+			"add %p, or use %H")
+	}
+	if u.year2 != nil && u.year == nil {
+		add(u.year2, SeverityInfo, "writes a two-digit year, which ParseL reads as 1969-2068", "use %Y")
+	}
+	if u.zone != nil && u.offset == nil {
+		add(u.zone, SeverityWarning, "writes a zone abbreviation, and ParseL only recognizes UTC and GMT", "add or use %z")
+	}
+	if u.isoWeek != nil && u.isoYear == nil && u.year != nil {
+		add(u.isoWeek, SeverityWarning, "writes an ISO 8601 week with the calendar year, which differ around New Year",
+			"use %G for the year")
+	}
+	week := u.weekNumber
+	if week == nil {
+		week = u.isoWeek
+	}
+	if week != nil && u.weekdayAny == nil {
+		add(week, SeverityInfo, "writes a week number without a weekday, so ParseL takes the first day of the week",
+			"add %a, %u or %w")
+	}
+	return problems
+}
@@ -0,0 +1,103 @@
+package strftime
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	type expect struct {
+		offset   int
+		spec     string
+		severity Severity
+		fix      string
+	}
+
+	tests := []struct {
+		format   string
+		expected []expect
+	}{
+		{"%Y-%m-%dT%H:%M:%S.%f%z", nil},
+		{"%c %x %X %r %D %F %T %R", nil},
+		{"100%% %Q", []expect{{6, "%Q", SeverityError, `use "%%Q" for literal text`}}},
+		{"%Y %", []expect{{3, "%", SeverityError, `use "%%" for a literal '%'`}}},
+		{"%Y %-", []expect{{3, "%-", SeverityError, `complete the specifier, or use "%%" for a literal '%'`}}},
+		{"%Y %:d", []expect{{3, "%:d", SeverityError, `use "%%:d" for literal text`}}},
+		{"%Ed %OB %Od %Ez", []expect{
+			{0, "%Ed", SeverityWarning, "use %d"},
+			{4, "%OB", SeverityWarning, "use %B"},
+		}},
+		{"%^d %-A %_Y %*B %-p", []expect{
+			{0, "%^d", SeverityWarning, "use %d"},
+			{4, "%-A", SeverityWarning, "use %A"},
+			{12, "%*B", SeverityWarning, "use %B"},
+			{16, "%-p", SeverityWarning, "use %p"},
+		}},
+		{"%3L %3N", []expect{{0, "%3L", SeverityWarning, "use %L"}}},
+		{"%k:%M %C%y %s", []expect{
+			{0, "%k", SeverityWarning, "use %_H"},
+			{6, "%C", SeverityWarning, "use %Y"},
+			{8, "%y", SeverityInfo, "use %Y"},
+			{11, "%s", SeverityWarning, "format it separately from the fields to be parsed"},
+		}},
+		{"%I:%M", []expect{{0, "%I", SeverityWarning, "add %p, or use %H"}}},
+		{"%I:%M %p", nil},
+		{"%d/%m/%y", []expect{{6, "%y", SeverityInfo, "use %Y"}}},
+		{"%H:%M %Z", []expect{{6, "%Z", SeverityWarning, "add or use %z"}}},
+		{"%H:%M %Z (%z)", nil},
+		{"%Y-W%V-%u", []expect{{4, "%V", SeverityWarning, "use %G for the year"}}},
+		{"%G-W%V", []expect{{4, "%V", SeverityInfo, "add %a, %u or %w"}}},
+		{"%Y %U %a", nil},
+	}
+
+	for _, tt := range tests {
+		diags := Validate(tt.format)
+		if len(diags) != len(tt.expected) {
+			t.Errorf("Validate(%q): got %d diagnostics %v, expected %d", tt.format, len(diags), diags, len(tt.expected))
+			continue
+		}
+		for k, d := range diags {
+			e := tt.expected[k]
+			if d.Offset != e.offset || d.Specifier != e.spec || d.Severity != e.severity || d.Fix != e.fix {
+				t.Errorf("Validate(%q)[%d]: got [%d %s %s %s], expected [%d %s %s %s]",
+					tt.format, k, d.Offset, d.Specifier, d.Severity, d.Fix, e.offset, e.spec, e.severity, e.fix)
+			}
+		}
+	}
+}
+
+func TestValidate_Options(t *testing.T) {
+	// The '^' flag changes %p in a locale with lowercase markers
+	lower := *DefaultLocale
+	lower.AM, lower.PM = "am", "pm"
+	if diags := Validate("%I %^p", WithLocale(&lower)); len(diags) != 1 || diags[0].Specifier != "%^p" ||
+		!strings.Contains(diags[0].Message, "ParseL") {
+		t.Errorf("Validate with a lowercase locale: got %v", diags)
+	}
+	if diags := Validate("%I %^p"); len(diags) != 1 || !strings.Contains(diags[0].Message, "'^' flag") {
+		t.Errorf("Validate with the default locale: got %v", diags)
+	}
+
+	// A registered specifier without a ParseFunc can't be read back
+	set := NewSpecifierSet()
+	if err := set.Register('Q', func(dst []byte, _ time.Time, _ *Locale, _ Modifiers) []byte { return append(dst, 'Q') }, nil); err != nil {
+		t.Fatal(err)
+	}
+	if diags := Validate("%Q", WithSpecifiers(set)); len(diags) != 1 || diags[0].Severity != SeverityWarning {
+		t.Errorf("Validate with a custom specifier: got %v", diags)
+	}
+}
+
+func TestValidate_ParseableEquivalents(t *testing.T) {
+	for spec, equivalent := range parseableEquivalents {
+		if !strings.HasPrefix(equivalent, "%") {
+			continue
+		}
+		for _, d := range Validate(equivalent) {
+			if strings.Contains(d.Message, "ParseL") {
+				t.Errorf("Suggested replacement %s for %%%c can't be parsed: %v", equivalent, spec, d)
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package strftime
+
+import (
+	"cmp"
+	"time"
+)
+
+// ZoneName holds the localized names of a time zone or metazone, as in CLDR.
+// Empty names fall back to the metazone's, and then to the locale's GMT format.
+type ZoneName struct {
+	Generic       string // Long generic name written by %:::Z, such as "Pacific Time"; Standard is used when empty
+	Standard      string // Long standard name written by %::Z, such as "Pacific Standard Time"
+	Daylight      string // Long daylight saving name written by %::Z, such as "Pacific Daylight Time"
+	ShortStandard string // Short standard name written by %:Z, such as "PST"
+	ShortDaylight string // Short daylight saving name written by %:Z, such as "PDT"
+}
+
+// Forms of the localized zone name, selected by the number of colons in %:Z, %::Z and %:::Z
+const (
+	zoneShort   = 1 // Short specific name, such as "PST"
+	zoneLong    = 2 // Long specific name, such as "Pacific Standard Time"
+	zoneGeneric = 3 // Long generic name, such as "Pacific Time"
+)
+
+// Default GMT formats of the localized zone name fallback
+const (
+	defaultGMTFormat     = "GMT%:z"
+	defaultGMTZeroFormat = "GMT"
+)
+
+// Metazones maps IANA zone IDs to the CLDR metazone whose names they share.
+// Zones missing from the map can still be named by their ID in Locale.ZoneNames.
+var Metazones = map[string]string{
+	"UTC":     "UTC",
+	"Etc/UTC": "UTC",
+	"Etc/GMT": "GMT",
+
+	"America/Los_Angeles":          "America_Pacific",
+	"America/Vancouver":            "America_Pacific",
+	"America/Tijuana":              "America_Pacific",
+	"America/Denver":               "America_Mountain",
+	"America/Phoenix":              "America_Mountain",
+	"America/Boise":                "America_Mountain",
+	"America/Edmonton":             "America_Mountain",
+	"America/Chicago":              "America_Central",
+	"America/Winnipeg":             "America_Central",
+	"America/Mexico_City":          "America_Central",
+	"America/New_York":             "America_Eastern",
+	"America/Detroit":              "America_Eastern",
+	"America/Toronto":              "America_Eastern",
+	"America/Indiana/Indianapolis": "America_Eastern",
+	"America/Anchorage":            "Alaska",
+	"Pacific/Honolulu":             "Hawaii_Aleutian",
+	"America/Halifax":              "Atlantic",
+	"America/Puerto_Rico":          "Atlantic",
+	"Atlantic/Bermuda":             "Atlantic",
+
+	"Europe/London":      "GMT",
+	"Europe/Dublin":      "GMT",
+	"Africa/Abidjan":     "GMT",
+	"Atlantic/Reykjavik": "GMT",
+	"Europe/Lisbon":      "Europe_Western",
+	"Atlantic/Canary":    "Europe_Western",
+	"Europe/Amsterdam":   "Europe_Central",
+	"Europe/Berlin":      "Europe_Central",
+	"Europe/Brussels":    "Europe_Central",
+	"Europe/Budapest":    "Europe_Central",
+	"Europe/Copenhagen":  "Europe_Central",
+	"Europe/Madrid":      "Europe_Central",
+	"Europe/Oslo":        "Europe_Central",
+	"Europe/Paris":       "Europe_Central",
+	"Europe/Prague":      "Europe_Central",
+	"Europe/Rome":        "Europe_Central",
+	"Europe/Stockholm":   "Europe_Central",
+	"Europe/Vienna":      "Europe_Central",
+	"Europe/Warsaw":      "Europe_Central",
+	"Europe/Zurich":      "Europe_Central",
+	"Europe/Athens":      "Europe_Eastern",
+	"Europe/Bucharest":   "Europe_Eastern",
+	"Europe/Helsinki":    "Europe_Eastern",
+	"Europe/Kyiv":        "Europe_Eastern",
+	"Europe/Kiev":        "Europe_Eastern",
+	"Europe/Sofia":       "Europe_Eastern",
+	"Africa/Cairo":       "Europe_Eastern",
+
+	"Asia/Shanghai":       "China",
+	"Asia/Chongqing":      "China",
+	"Asia/Macau":          "China",
+	"Asia/Tokyo":          "Japan",
+	"Asia/Seoul":          "Korea",
+	"Asia/Kolkata":        "India",
+	"Asia/Calcutta":       "India",
+	"Australia/Sydney":    "Australia_Eastern",
+	"Australia/Melbourne": "Australia_Eastern",
+	"Australia/Brisbane":  "Australia_Eastern",
+	"Australia/Hobart":    "Australia_Eastern",
+}
+
+// EnglishZoneNames holds English names of common metazones, used by DefaultLocale
+var EnglishZoneNames = map[string]ZoneName{
+	"UTC":               {Standard: "Coordinated Universal Time", ShortStandard: "UTC"},
+	"GMT":               {Standard: "Greenwich Mean Time", ShortStandard: "GMT"},
+	"Europe/London":     {Daylight: "British Summer Time", ShortDaylight: "BST"},
+	"Europe/Dublin":     {Daylight: "Irish Standard Time"},
+	"America_Pacific":   {Generic: "Pacific Time", Standard: "Pacific Standard Time", Daylight: "Pacific Daylight Time", ShortStandard: "PST", ShortDaylight: "PDT"},
+	"America_Mountain":  {Generic: "Mountain Time", Standard: "Mountain Standard Time", Daylight: "Mountain Daylight Time", ShortStandard: "MST", ShortDaylight: "MDT"},
+	"America_Central":   {Generic: "Central Time", Standard: "Central Standard Time", Daylight: "Central Daylight Time", ShortStandard: "CST", ShortDaylight: "CDT"},
+	"America_Eastern":   {Generic: "Eastern Time", Standard: "Eastern Standard Time", Daylight: "Eastern Daylight Time", ShortStandard: "EST", ShortDaylight: "EDT"},
+	"Alaska":            {Generic: "Alaska Time", Standard: "Alaska Standard Time", Daylight: "Alaska Daylight Time", ShortStandard: "AKST", ShortDaylight: "AKDT"},
+	"Hawaii_Aleutian":   {Generic: "Hawaii-Aleutian Time", Standard: "Hawaii-Aleutian Standard Time", Daylight: "Hawaii-Aleutian Daylight Time", ShortStandard: "HST", ShortDaylight: "HDT"},
+	"Atlantic":          {Generic: "Atlantic Time", Standard: "Atlantic Standard Time", Daylight: "Atlantic Daylight Time", ShortStandard: "AST", ShortDaylight: "ADT"},
+	"Europe_Western":    {Generic: "Western European Time", Standard: "Western European Standard Time", Daylight: "Western European Summer Time"},
+	"Europe_Central":    {Generic: "Central European Time", Standard: "Central European Standard Time", Daylight: "Central European Summer Time"},
+	"Europe_Eastern":    {Generic: "Eastern European Time", Standard: "Eastern European Standard Time", Daylight: "Eastern European Summer Time"},
+	"China":             {Generic: "China Time", Standard: "China Standard Time", Daylight: "China Daylight Time"},
+	"Japan":             {Generic: "Japan Time", Standard: "Japan Standard Time", Daylight: "Japan Daylight Time"},
+	"Korea":             {Generic: "Korean Time", Standard: "Korean Standard Time", Daylight: "Korean Daylight Time"},
+	"India":             {Standard: "India Standard Time"},
+	"Australia_Eastern": {Generic: "Eastern Australia Time", Standard: "Australian Eastern Standard Time", Daylight: "Australian Eastern Daylight Time"},
+}
+
+// FrenchZoneNames holds French names of common metazones, for use with GMTFormat "UTC%:z" and GMTZeroFormat "UTC"
+var FrenchZoneNames = map[string]ZoneName{
+	"UTC":               {Standard: "temps universel coordonné", ShortStandard: "UTC"},
+	"GMT":               {Standard: "heure moyenne de Greenwich", ShortStandard: "UTC"},
+	"America_Pacific":   {Generic: "heure du Pacifique", Standard: "heure normale du Pacifique", Daylight: "heure d’été du Pacifique"},
+	"America_Mountain":  {Generic: "heure des Rocheuses", Standard: "heure normale des Rocheuses", Daylight: "heure d’été des Rocheuses"},
+	"America_Central":   {Generic: "heure du centre nord-américain", Standard: "heure normale du centre nord-américain", Daylight: "heure d’été du centre nord-américain"},
+	"America_Eastern":   {Generic: "heure de l’Est nord-américain", Standard: "heure normale de l’Est nord-américain", Daylight: "heure d’été de l’Est nord-américain"},
+	"Europe_Western":    {Generic: "heure d’Europe de l’Ouest", Standard: "heure normale d’Europe de l’Ouest", Daylight: "heure d’été d’Europe de l’Ouest"},
+	"Europe_Central":    {Generic: "heure d’Europe centrale", Standard: "heure normale d’Europe centrale", Daylight: "heure d’été d’Europe centrale"},
+	"Europe_Eastern":    {Generic: "heure d’Europe de l’Est", Standard: "heure normale d’Europe de l’Est", Daylight: "heure d’été d’Europe de l’Est"},
+	"China":             {Generic: "heure de la Chine", Standard: "heure normale de la Chine", Daylight: "heure d’été de Chine"},
+	"Japan":             {Generic: "heure du Japon", Standard: "heure normale du Japon", Daylight: "heure d’été du Japon"},
+	"India":             {Standard: "heure de l’Inde"},
+	"Australia_Eastern": {Generic: "heure de l’Est de l’Australie", Standard: "heure normale de l’Est de l’Australie", Daylight: "heure d’été de l’Est de l’Australie"},
+}
+
+// ChineseZoneNames holds Simplified Chinese names of common metazones
+var ChineseZoneNames = map[string]ZoneName{
+	"UTC":               {Standard: "协调世界时", ShortStandard: "UTC"},
+	"GMT":               {Standard: "格林尼治标准时间"},
+	"America_Pacific":   {Generic: "北美太平洋时间", Standard: "北美太平洋标准时间", Daylight: "北美太平洋夏令时间"},
+	"America_Mountain":  {Generic: "北美山区时间", Standard: "北美山区标准时间", Daylight: "北美山区夏令时间"},
+	"America_Central":   {Generic: "北美中部时间", Standard: "北美中部标准时间", Daylight: "北美中部夏令时间"},
+	"America_Eastern":   {Generic: "北美东部时间", Standard: "北美东部标准时间", Daylight: "北美东部夏令时间"},
+	"Europe_Western":    {Generic: "西欧时间", Standard: "西欧标准时间", Daylight: "西欧夏令时间"},
+	"Europe_Central":    {Generic: "中欧时间", Standard: "中欧标准时间", Daylight: "中欧夏令时间"},
+	"Europe_Eastern":    {Generic: "东欧时间", Standard: "东欧标准时间", Daylight: "东欧夏令时间"},
+	"China":             {Generic: "中国时间", Standard: "中国标准时间", Daylight: "中国夏令时间"},
+	"Japan":             {Generic: "日本时间", Standard: "日本标准时间", Daylight: "日本夏令时间"},
+	"Korea":             {Generic: "韩国时间", Standard: "韩国标准时间", Daylight: "韩国夏令时间"},
+	"India":             {Standard: "印度时间"},
+	"Australia_Eastern": {Generic: "澳大利亚东部时间", Standard: "澳大利亚东部标准时间", Daylight: "澳大利亚东部夏令时间"},
+}
+
+// zoneName returns the localized name of t's zone in the given form, or "" if the locale has none.
+// Names given for the IANA zone ID take precedence over those of its metazone.
+func (l *Locale) zoneName(t time.Time, form int) string {
+	id := t.Location().String()
+	zone := l.ZoneNames[id]
+	var meta ZoneName
+	if mz, ok := Metazones[id]; ok {
+		meta = l.ZoneNames[mz]
+	}
+	dst := isDaylight(t)
+	switch form {
+	case zoneShort:
+		if dst {
+			return cmp.Or(zone.ShortDaylight, meta.ShortDaylight)
+		}
+		return cmp.Or(zone.ShortStandard, meta.ShortStandard)
+	case zoneLong:
+		if dst {
+			return cmp.Or(zone.Daylight, meta.Daylight)
+		}
+		return cmp.Or(zone.Standard, meta.Standard)
+	case zoneGeneric:
+		return cmp.Or(zone.Generic, meta.Generic, zone.Standard, meta.Standard)
+	}
+	return ""
+}
+
+// isDaylight reports whether t is in the daylight saving time of its zone, taken as the larger of the zone's
+// offsets in January and July of t's year. Unlike t.IsDST, this holds for zones such as Europe/Dublin,
+// where tzdata marks winter time as negative daylight saving time.
+func isDaylight(t time.Time) bool {
+	loc := t.Location()
+	_, offset := t.Zone()
+	_, january := time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, loc).Zone()
+	_, july := time.Date(t.Year(), time.July, 1, 0, 0, 0, 0, loc).Zone()
+	return january != july && offset == max(january, july)
+}
+
+// gmtFormat returns the pattern written for t's offset when its zone has no localized name
+func (l *Locale) gmtFormat(t time.Time) string {
+	if _, offset := t.Zone(); offset == 0 {
+		return cmp.Or(l.GMTZeroFormat, defaultGMTZeroFormat)
+	}
+	return cmp.Or(l.GMTFormat, defaultGMTFormat)
+}
@@ -0,0 +1,110 @@
+package strftime
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestZoneNames(t *testing.T) {
+	mustLoad := func(name string) *time.Location {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatalf("LoadLocation(%q) returned error: %v", name, err)
+		}
+		return loc
+	}
+	la := mustLoad("America/Los_Angeles")
+	london := mustLoad("Europe/London")
+	dublin := mustLoad("Europe/Dublin")
+	paris := mustLoad("Europe/Paris")
+	kolkata := mustLoad("Asia/Kolkata")
+
+	winter := time.Date(2025, time.January, 15, 12, 0, 0, 0, la)
+	summer := time.Date(2025, time.July, 15, 12, 0, 0, 0, la)
+
+	french := *DefaultLocale
+	french.ZoneNames = FrenchZoneNames
+	french.GMTFormat = "UTC%:z"
+	french.GMTZeroFormat = "UTC"
+
+	chinese := *DefaultLocale
+	chinese.ZoneNames = ChineseZoneNames
+
+	noNames := *DefaultLocale
+	noNames.ZoneNames = nil
+
+	tests := []struct {
+		format   string
+		t        time.Time
+		locale   *Locale
+		expected string
+	}{
+		{"%Z", winter, DefaultLocale, "PST"},
+		{"%:Z", winter, DefaultLocale, "PST"},
+		{"%:Z", summer, DefaultLocale, "PDT"},
+		{"%::Z", winter, DefaultLocale, "Pacific Standard Time"},
+		{"%::Z", summer, DefaultLocale, "Pacific Daylight Time"},
+		{"%:::Z", summer, DefaultLocale, "Pacific Time"},
+		{"%::Z", winter, &french, "heure normale du Pacifique"},
+		{"%::Z", winter, &chinese, "北美太平洋标准时间"},
+		{"%:::Z", summer, &chinese, "北美太平洋时间"},
+
+		// Zone-specific names take precedence over the metazone's
+		{"%::Z", time.Date(2025, time.July, 1, 12, 0, 0, 0, london), DefaultLocale, "British Summer Time"},
+		{"%::Z", time.Date(2025, time.January, 1, 12, 0, 0, 0, london), DefaultLocale, "Greenwich Mean Time"},
+		{"%::Z", time.Date(2025, time.July, 1, 12, 0, 0, 0, paris), DefaultLocale, "Central European Summer Time"},
+		{"%:Z", time.Date(2025, time.July, 1, 12, 0, 0, 0, london), DefaultLocale, "BST"},
+		{"%:Z", time.Date(2025, time.January, 1, 12, 0, 0, 0, london), DefaultLocale, "GMT"},
+
+		// tzdata marks Irish winter time as negative daylight saving time
+		{"%::Z", time.Date(2025, time.January, 15, 12, 0, 0, 0, dublin), DefaultLocale, "Greenwich Mean Time"},
+		{"%::Z", time.Date(2025, time.July, 15, 12, 0, 0, 0, dublin), DefaultLocale, "Irish Standard Time"},
+		{"%:Z", time.Date(2025, time.January, 15, 12, 0, 0, 0, dublin), DefaultLocale, "GMT"},
+
+		// Generic names fall back to the standard name
+		{"%:::Z", time.Date(2025, time.July, 1, 12, 0, 0, 0, kolkata), DefaultLocale, "India Standard Time"},
+
+		// Missing names fall back to the GMT format
+		{"%:Z", time.Date(2025, time.July, 1, 12, 0, 0, 0, paris), DefaultLocale, "GMT+02:00"},
+		{"%:Z", summer, &french, "UTC-07:00"},
+		{"%::Z", winter, &noNames, "GMT-08:00"},
+		{"%::Z", time.Date(2025, time.July, 1, 12, 0, 0, 0, time.FixedZone("", 5*3600+1800)), DefaultLocale, "GMT+05:30"},
+		{"%::Z", time.Date(2025, time.July, 1, 12, 0, 0, 0, time.FixedZone("", 0)), DefaultLocale, "GMT"},
+		{"%::Z", time.Date(2025, time.July, 1, 12, 0, 0, 0, time.FixedZone("", 0)), &french, "UTC"},
+		{"%::Z", time.Date(2025, time.July, 1, 12, 0, 0, 0, time.UTC), DefaultLocale, "Coordinated Universal Time"},
+		{"%:Z", time.Date(2025, time.July, 1, 12, 0, 0, 0, time.UTC), DefaultLocale, "UTC"},
+
+		// Flags apply to the whole name
+		{"%^::Z", winter, DefaultLocale, "PACIFIC STANDARD TIME"},
+		{"[%12:Z]", winter, DefaultLocale, "[         PST]"},
+		{"%^::Z", winter, &noNames, "GMT-08:00"},
+		{"%::::Z", winter, DefaultLocale, "Z"},
+	}
+	for _, tt := range tests {
+		formatted := StrftimeL(tt.format, tt.t, tt.locale)
+		if formatted != tt.expected {
+			t.Errorf("StrftimeL(%q, %v): got [%s], expected [%s]", tt.format, tt.t, formatted, tt.expected)
+		}
+		f := MustCompile(tt.format, WithLocale(tt.locale))
+		if formatted := f.Format(tt.t); formatted != tt.expected {
+			t.Errorf("Compiled %q at %v: got [%s], expected [%s]", tt.format, tt.t, formatted, tt.expected)
+		}
+	}
+}
+
+func TestZoneNameAllocs(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testTime := time.Date(2025, time.January, 15, 12, 0, 0, 0, la)
+	f := MustCompile("%::Z %:Z %:::Z")
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = f.AppendFormat(buf[:0], testTime)
+	})
+	if allocs != 0 {
+		t.Errorf("AppendFormat with zone names: got %v allocations, expected 0", allocs)
+	}
+}